@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Node is an element of the tree returned by Parse
+type Node interface {
+	String() string
+	node()
+}
+
+// AndNode is Left and Right
+type AndNode struct {
+	Left  Node
+	Right Node
+}
+
+// OrNode is Left or Right
+type OrNode struct {
+	Left  Node
+	Right Node
+}
+
+// NotNode is not Operand
+type NotNode struct {
+	Operand Node
+}
+
+// XorNode is Left xor Right, true when exactly one of them is true
+type XorNode struct {
+	Left  Node
+	Right Node
+}
+
+// NandNode is Left nand Right, that is not (Left and Right)
+type NandNode struct {
+	Left  Node
+	Right Node
+}
+
+// NorNode is Left nor Right, that is not (Left or Right)
+type NorNode struct {
+	Left  Node
+	Right Node
+}
+
+// ImpliesNode is Left implies Right, that is not Left or Right
+type ImpliesNode struct {
+	Left  Node
+	Right Node
+}
+
+// TermNode is a search term, Value is what Str receives or, when Field
+// is set, what the handler of Field receives.
+// Quoted tells the value was written as a phrase between quotes and
+// Wildcard tells the value was not quoted and contains * or ?
+type TermNode struct {
+	Field    string
+	Value    string
+	Quoted   bool
+	Wildcard bool
+}
+
+// ComparisonNode is Field Operator Value, like price>=10. Operator is
+// one of =, !=, >, >=, < and <=
+type ComparisonNode struct {
+	Field    string
+	Operator string
+	Value    string
+	Quoted   bool
+}
+
+// RangeNode is Field:[Lower TO Upper]. A bracket includes its bound and
+// a brace excludes it, an empty bound is open and written *
+type RangeNode struct {
+	Field        string
+	Lower        string
+	Upper        string
+	IncludeLower bool
+	IncludeUpper bool
+}
+
+// InNode is Field:(Values...), matching any of the values
+type InNode struct {
+	Field  string
+	Values []string
+}
+
+// RegexNode is /Pattern/Flags, or Field:/Pattern/Flags to match another
+// column than the default one. The only flag is i, to ignore case
+type RegexNode struct {
+	Field   string
+	Pattern string
+	Flags   string
+}
+
+// IgnoreCase tells if the regex has the flag i
+func (n *RegexNode) IgnoreCase() bool {
+	return strings.Contains(n.Flags, regexIgnoreCase)
+}
+
+// GroupNode is an expression in parentheses
+type GroupNode struct {
+	Inner Node
+}
+
+func (*AndNode) node()        {}
+func (*OrNode) node()         {}
+func (*NotNode) node()        {}
+func (*XorNode) node()        {}
+func (*NandNode) node()       {}
+func (*NorNode) node()        {}
+func (*ImpliesNode) node()    {}
+func (*TermNode) node()       {}
+func (*ComparisonNode) node() {}
+func (*RangeNode) node()      {}
+func (*InNode) node()         {}
+func (*RegexNode) node()      {}
+func (*GroupNode) node()      {}
+
+func (n *AndNode) String() string {
+	return n.Left.String() + " " + operatorAnd + " " + n.Right.String()
+}
+
+func (n *OrNode) String() string {
+	return n.Left.String() + " " + operatorOr + " " + n.Right.String()
+}
+
+func (n *NotNode) String() string {
+	return operatorNot + " " + n.Operand.String()
+}
+
+func (n *XorNode) String() string {
+	return n.Left.String() + " " + operatorXor + " " + n.Right.String()
+}
+
+func (n *NandNode) String() string {
+	return n.Left.String() + " " + operatorNand + " " + n.Right.String()
+}
+
+func (n *NorNode) String() string {
+	return n.Left.String() + " " + operatorNor + " " + n.Right.String()
+}
+
+func (n *ImpliesNode) String() string {
+	return n.Left.String() + " " + operatorImplies + " " + n.Right.String()
+}
+
+// Expand returns (Left and not Right) or (not Left and Right)
+func (n *XorNode) Expand() Node {
+	return &OrNode{
+		Left:  &GroupNode{Inner: &AndNode{Left: n.Left, Right: &NotNode{Operand: n.Right}}},
+		Right: &GroupNode{Inner: &AndNode{Left: &NotNode{Operand: n.Left}, Right: n.Right}},
+	}
+}
+
+// Expand returns not (Left and Right)
+func (n *NandNode) Expand() Node {
+	return &NotNode{Operand: &GroupNode{Inner: &AndNode{Left: n.Left, Right: n.Right}}}
+}
+
+// Expand returns not (Left or Right)
+func (n *NorNode) Expand() Node {
+	return &NotNode{Operand: &GroupNode{Inner: &OrNode{Left: n.Left, Right: n.Right}}}
+}
+
+// Expand returns not Left or Right
+func (n *ImpliesNode) Expand() Node {
+	return &OrNode{Left: &NotNode{Operand: n.Left}, Right: n.Right}
+}
+
+func (n *TermNode) String() string {
+	value := n.Value
+	if n.Quoted {
+		value = quote(n.Value)
+	}
+
+	if n.Field != "" {
+		return n.Field + fieldSeparator + value
+	}
+
+	return value
+}
+
+func (n *ComparisonNode) String() string {
+	value := n.Value
+	if n.Quoted {
+		value = quote(n.Value)
+	}
+
+	return n.Field + n.Operator + value
+}
+
+func (n *RangeNode) String() string {
+	opening, closing := rangeExclusiveOpen, rangeExclusiveClose
+	if n.IncludeLower {
+		opening = rangeInclusiveOpen
+	}
+
+	if n.IncludeUpper {
+		closing = rangeInclusiveClose
+	}
+
+	lower, upper := n.Lower, n.Upper
+	if lower == "" {
+		lower = rangeOpenBound
+	}
+
+	if upper == "" {
+		upper = rangeOpenBound
+	}
+
+	return n.Field + fieldSeparator + opening + lower + " " + rangeTo + " " + upper + closing
+}
+
+func (n *InNode) String() string {
+	values := make([]string, len(n.Values))
+	for i, value := range n.Values {
+		values[i] = value
+		if !isPlainValue(value) {
+			values[i] = quote(value)
+		}
+	}
+
+	return n.Field + fieldSeparator + openExp + strings.Join(values, listSeparator+" ") + closeExp
+}
+
+func (n *RegexNode) String() string {
+	regex := regexDelimiter + strings.ReplaceAll(n.Pattern, regexDelimiter, escape+regexDelimiter) + regexDelimiter + n.Flags
+	if n.Field != "" {
+		return n.Field + fieldSeparator + regex
+	}
+
+	return regex
+}
+
+func (n *GroupNode) String() string {
+	return openExp + n.Inner.String() + closeExp
+}
+
+// Walk visits n and its children depth-first, skipping the children
+// of a node when visit returns false
+func Walk(n Node, visit func(Node) bool) {
+	if !visit(n) {
+		return
+	}
+
+	switch n := n.(type) {
+	case *AndNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *OrNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *NotNode:
+		Walk(n.Operand, visit)
+	case *XorNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *NandNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *NorNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *ImpliesNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *GroupNode:
+		Walk(n.Inner, visit)
+	}
+}
+
+// isPlainValue tells if a list value reads back without quotes
+func isPlainValue(s string) bool {
+	return s != "" && !strings.ContainsAny(s, listSeparator+openExp+closeExp+quoteDouble+quoteSingle) &&
+		strings.IndexFunc(s, unicode.IsSpace) < 0
+}
+
+// unwrap removes the groups around n
+func unwrap(n Node) Node {
+	for {
+		g, ok := n.(*GroupNode)
+		if !ok {
+			return n
+		}
+
+		n = g.Inner
+	}
+}
+
+// isWildcard tells if s contains * or ?
+func isWildcard(s string) bool {
+	return strings.ContainsAny(s, wildcardAny+wildcardOne)
+}
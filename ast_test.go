@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parse_tree_case1(t *testing.T) {
+	p := New(nil)
+
+	n, err := p.Parse("not (alice or bob) and carol")
+	assert.Nil(t, err)
+
+	expected := &AndNode{
+		Left: &NotNode{
+			Operand: &GroupNode{
+				Inner: &OrNode{
+					Left:  &TermNode{Value: "alice"},
+					Right: &TermNode{Value: "bob"},
+				},
+			},
+		},
+		Right: &TermNode{Value: "carol"},
+	}
+	assert.Equal(t, expected, n)
+}
+
+func Test_parse_tree_case2(t *testing.T) {
+	p := New(nil)
+
+	n, err := p.Parse("alice or bob or carol")
+	assert.Nil(t, err)
+
+	expected := &OrNode{
+		Left: &OrNode{
+			Left:  &TermNode{Value: "alice"},
+			Right: &TermNode{Value: "bob"},
+		},
+		Right: &TermNode{Value: "carol"},
+	}
+	assert.Equal(t, expected, n)
+}
+
+func Test_parse_tree_string(t *testing.T) {
+	p := New(nil)
+
+	cases := []string{
+		"alice",
+		"not alice",
+		"(alice or bob) and not carol",
+		"alice or (bob and (not carol or dan))",
+	}
+
+	for _, input := range cases {
+		n, err := p.Parse(input)
+		assert.Nil(t, err)
+		assert.Equal(t, input, n.String())
+	}
+}
+
+func Test_parse_tree_walk(t *testing.T) {
+	p := New(nil)
+
+	n, err := p.Parse("(alice or bob) and not (carol and dan)")
+	assert.Nil(t, err)
+
+	terms := []string{}
+	Walk(n, func(n Node) bool {
+		if _, ok := n.(*NotNode); ok {
+			return false
+		}
+
+		if term, ok := n.(*TermNode); ok {
+			terms = append(terms, term.Value)
+		}
+
+		return true
+	})
+	assert.Equal(t, []string{"alice", "bob"}, terms)
+}
+
+func Test_parse_tree_compile(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	n, err := p.Parse("alice and not bob")
+	assert.Nil(t, err)
+
+	Walk(n, func(n Node) bool {
+		if term, ok := n.(*TermNode); ok {
+			term.Value = strings.ToUpper(term.Value)
+		}
+
+		return true
+	})
+
+	exp, err := p.Compile(n)
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "(col = ? AND NOT (col = ?))", sql)
+	assert.Equal(t, []interface{}{"ALICE", "BOB"}, v)
+}
+
+func Test_parse_does_not_call_callbacks(t *testing.T) {
+	p := parser2{
+		Str: func(s string) squirrel.Sqlizer {
+			t.Error("Str must not be called by Parse")
+			return nil
+		},
+	}
+
+	_, err := p.Parse("alice")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_boolean_operators_expanded(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithKeywords(ExtendedKeywords()))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"alice xor bob",
+			[]interface{}{"alice", "bob", "alice", "bob"},
+			"((col = ? AND NOT (col = ?)) OR (NOT (col = ?) AND col = ?))",
+		},
+		{
+			"alice NAND bob",
+			[]interface{}{"alice", "bob"},
+			"NOT ((col = ? AND col = ?))",
+		},
+		{
+			"alice nor bob",
+			[]interface{}{"alice", "bob"},
+			"NOT ((col = ? OR col = ?))",
+		},
+		{
+			"alice implies bob",
+			[]interface{}{"alice", "bob"},
+			"(NOT (col = ?) OR col = ?)",
+		},
+		{
+			"alice implies not (bob or carol)",
+			[]interface{}{"alice", "bob", "carol"},
+			"(NOT (col = ?) OR NOT ((col = ? OR col = ?)))",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_boolean_operators_native(t *testing.T) {
+	native := func(op string) func(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+		return func(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+			return squirrel.ConcatExpr("(", a, " "+op+" ", b, ")")
+		}
+	}
+
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	},
+		WithKeywords(ExtendedKeywords()),
+		WithXor(native("XOR")),
+		WithNand(native("NAND")),
+		WithNor(native("NOR")),
+		WithImplies(native("IMPLIES")),
+	)
+
+	exp, err := p.Go("alice xor bob nand carol nor dave implies eve")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice", "bob", "carol", "dave", "eve"}, v)
+	assert.Equal(t, "(((col = ? XOR (col = ? NAND col = ?)) NOR col = ?) IMPLIES col = ?)", sql)
+}
+
+func Test_boolean_operators_precedence(t *testing.T) {
+	p := New(nil, WithKeywords(ExtendedKeywords()))
+
+	a, b, c, d := &TermNode{Value: "a"}, &TermNode{Value: "b"}, &TermNode{Value: "c"}, &TermNode{Value: "d"}
+
+	cases := []struct {
+		input    string
+		expected Node
+	}{
+		{
+			"a or b xor c and d",
+			&OrNode{Left: a, Right: &XorNode{Left: b, Right: &AndNode{Left: c, Right: d}}},
+		},
+		{
+			"a nor b nand c xor d",
+			&NorNode{Left: a, Right: &XorNode{Left: &NandNode{Left: b, Right: c}, Right: d}},
+		},
+		{
+			"a implies b implies c",
+			&ImpliesNode{Left: a, Right: &ImpliesNode{Left: b, Right: c}},
+		},
+		{
+			"a and b implies c or d",
+			&ImpliesNode{Left: &AndNode{Left: a, Right: b}, Right: &OrNode{Left: c, Right: d}},
+		},
+		{
+			"(a implies b) implies not c",
+			&ImpliesNode{Left: &GroupNode{Inner: &ImpliesNode{Left: a, Right: b}}, Right: &NotNode{Operand: c}},
+		},
+	}
+
+	for _, curr := range cases {
+		n, err := p.Parse(curr.input)
+		assert.Nil(t, err, curr.input)
+		assert.Equal(t, curr.expected, n, curr.input)
+		assert.Equal(t, curr.input, n.String())
+	}
+}
+
+func Test_boolean_operators_disabled(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	exp, err := p.Go("alice xor bob or carol")
+	assert.Nil(t, err)
+
+	_, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice xor bob", "carol"}, v)
+
+	p = New(nil, WithKeywords(ExtendedKeywords()))
+
+	_, err = p.Parse("alice implies")
+	assert.True(t, errors.Is(err, ErrorOperators))
+	assert.EqualError(t, err, "operator do not match at 1:14: expected term after 'implies', found end of input")
+}
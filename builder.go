@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"github.com/Masterminds/squirrel"
+)
+
+// Builder turns the terms and the operators of an expression into T.
+// In New, field terms, comparisons, ranges, lists, wildcards and regexes
+// keep their own compilers and reach And, Or and Not as operands
+type Builder[T any] interface {
+	Term(search string) T
+	And(a, b T) T
+	Or(a, b T) T
+	Not(a T) T
+}
+
+// LeafBuilder is a Builder that also compiles, in NewOf, the field terms,
+// wildcards, comparisons, ranges, lists and regexes
+type LeafBuilder[T any] interface {
+	Builder[T]
+	Leaf(n Node) (T, error)
+}
+
+// PhraseBuilder is a Builder that also compiles, in NewOf, the phrases
+// written without field, which go to Term otherwise
+type PhraseBuilder[T any] interface {
+	Builder[T]
+	Phrase(search string) T
+}
+
+// StrBuilder is the Builder used by New: terms go to the function and
+// the operators emit squirrel.And, squirrel.Or and Not. Embed it to
+// replace some of the methods only
+type StrBuilder func(search string) squirrel.Sqlizer
+
+// Term returns Str(search)
+func (Str StrBuilder) Term(search string) squirrel.Sqlizer {
+	return Str(search)
+}
+
+// And returns squirrel.And{a, b}
+func (StrBuilder) And(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+	return squirrel.And{a, b}
+}
+
+// Or returns squirrel.Or{a, b}
+func (StrBuilder) Or(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+	return squirrel.Or{a, b}
+}
+
+// Not returns Not{a}
+func (StrBuilder) Not(a squirrel.Sqlizer) squirrel.Sqlizer {
+	return Not{Operand: a}
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+// tsBuilder matches full-text terms and writes its own operators
+type tsBuilder struct{}
+
+func (tsBuilder) Term(search string) squirrel.Sqlizer {
+	return squirrel.Expr("doc @@ plainto_tsquery(?)", search)
+}
+
+func (tsBuilder) And(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+	return squirrel.ConcatExpr("(", a, " AND ", b, ")")
+}
+
+func (tsBuilder) Or(a, b squirrel.Sqlizer) squirrel.Sqlizer {
+	return squirrel.ConcatExpr("(", a, " OR ", b, ")")
+}
+
+func (tsBuilder) Not(a squirrel.Sqlizer) squirrel.Sqlizer {
+	return squirrel.ConcatExpr("NOT ", a)
+}
+
+// upperBuilder only replaces Term
+type upperBuilder struct {
+	StrBuilder
+}
+
+func (b upperBuilder) Term(search string) squirrel.Sqlizer {
+	return b.StrBuilder.Term(search + "!")
+}
+
+func Test_builder_custom(t *testing.T) {
+	p := New(nil, WithBuilder(tsBuilder{}), WithComparisons("price"))
+
+	exp, err := p.Go("(alice or bob) and not carol and price>10")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice", "bob", "carol", "10"}, v)
+	assert.Equal(t, "(((doc @@ plainto_tsquery(?) OR doc @@ plainto_tsquery(?)) AND NOT doc @@ plainto_tsquery(?)) AND price > ?)", sql)
+}
+
+func Test_builder_embedded(t *testing.T) {
+	p := New(nil, WithBuilder(upperBuilder{StrBuilder(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})}))
+
+	exp, err := p.Go("alice or not bob")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice!", "bob!"}, v)
+	assert.Equal(t, "(col = ? OR NOT (col = ?))", sql)
+}
+
+func Test_builder_str(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}
+
+	exp, err := New(Str).Go("alice or bob and carol")
+	assert.Nil(t, err)
+	assert.Equal(t, squirrel.Or{Str("alice"), squirrel.And{Str("bob"), Str("carol")}}, exp)
+
+	_, err = New(nil).Go("alice or bob")
+	assert.Equal(t, ErrorNotDefinedStr, err)
+}
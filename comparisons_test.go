@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_comparisons(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("body LIKE ?", s)
+	}, WithComparisons("price", "created", "name"))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"price>=10 and created<2024-01-01",
+			[]interface{}{"10", "2024-01-01"},
+			"(price >= ? AND created < ?)",
+		},
+		{
+			"price>10 or price<=2",
+			[]interface{}{"10", "2"},
+			"(price > ? OR price <= ?)",
+		},
+		{
+			`name!="alice smith" and not name=bob`,
+			[]interface{}{"alice smith", "bob"},
+			"(name <> ? AND NOT (name = ?))",
+		},
+		{
+			"(price<5)and(cheap stuff)",
+			[]interface{}{"5", "cheap stuff"},
+			"(price < ? AND body LIKE ?)",
+		},
+		{
+			"a!b or 1<2",
+			[]interface{}{"a!b", "1<2"},
+			"(body LIKE ? OR body LIKE ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_comparisons_tree(t *testing.T) {
+	p := New(nil, WithComparisons("price"), WithField("status", nil))
+
+	n, err := p.Parse(`price>=10 and status:a>b and price!="x y"`)
+	assert.Nil(t, err)
+
+	expected := &AndNode{
+		Left: &AndNode{
+			Left:  &ComparisonNode{Field: "price", Operator: ">=", Value: "10"},
+			Right: &TermNode{Field: "status", Value: "a>b"},
+		},
+		Right: &ComparisonNode{Field: "price", Operator: "!=", Value: "x y", Quoted: true},
+	}
+	assert.Equal(t, expected, n)
+	assert.Equal(t, `price>=10 and status:a>b and price!="x y"`, n.String())
+}
+
+func Test_comparisons_callback(t *testing.T) {
+	CompareCalled := false
+	Compare := func(c *ComparisonNode) squirrel.Sqlizer {
+		assert.Equal(t, &ComparisonNode{Field: "created", Operator: "<", Value: "2024-01-01"}, c)
+
+		CompareCalled = true
+
+		return squirrel.Expr("created < ?::date", c.Value)
+	}
+
+	p := New(nil, WithComparisons("created"), WithCompare(Compare))
+
+	exp, err := p.Go("created<2024-01-01")
+	assert.Nil(t, err)
+	assert.True(t, CompareCalled)
+
+	sql, _, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "created < ?::date", sql)
+}
+
+func Test_comparisons_errors(t *testing.T) {
+	p := New(nil, WithComparisons("price", "created"))
+
+	_, err := p.Parse("price>1 and stock<3")
+
+	var fieldErr *UnknownFieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "stock", fieldErr.Field)
+	assert.Equal(t, []string{"created", "price"}, fieldErr.Allowed)
+
+	_, err = p.Parse("price>= 10")
+	assert.True(t, errors.Is(err, ErrorExpression))
+
+	for _, input := range []string{"price<>3", "price=>3", "price==3", "price!=!3"} {
+		_, err = p.Parse(input)
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), input) {
+			continue
+		}
+
+		assert.Equal(t, 0, syntaxErr.Offset, input)
+		assert.True(t, errors.Is(err, ErrorExpression), input)
+	}
+
+	n, err := p.Parse(`price="=3"`)
+	assert.Nil(t, err)
+	assert.Equal(t, &ComparisonNode{Field: "price", Operator: "=", Value: "=3", Quoted: true}, n)
+
+	_, err = (&parser2{}).Compile(&ComparisonNode{Field: "price", Operator: ">", Value: "1"})
+	assert.Equal(t, ErrorNotDefinedCompare, err)
+}
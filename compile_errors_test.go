@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+var errBroken = fmt.Errorf("broken")
+
+// broken is a Sqlizer that fails to render
+type broken struct{}
+
+func (broken) ToSql() (string, []interface{}, error) {
+	return "", nil, errBroken
+}
+
+func Test_compile_errors_str(t *testing.T) {
+	p := New(nil, WithStrE(func(s string) (squirrel.Sqlizer, error) {
+		day, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return nil, err
+		}
+
+		return squirrel.Eq{"day": day}, nil
+	}))
+
+	exp, err := p.Go("2020-01-02 or 2020-01-03")
+	assert.Nil(t, err)
+
+	sql, _, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "(day = ? OR day = ?)", sql)
+
+	_, err = p.Go("2020-01-02 or\n  not 2020-13-01")
+
+	var compileErr *CompileError
+	assert.True(t, errors.As(err, &compileErr))
+	assert.Equal(t, 20, compileErr.Offset)
+	assert.Equal(t, 2, compileErr.Line)
+	assert.Equal(t, 7, compileErr.Column)
+	assert.Equal(t, "2020-13-01", compileErr.Term)
+	assert.EqualError(t, err, `can not compile 2020-13-01 at 2:7: parsing time "2020-13-01": month out of range`)
+}
+
+func Test_compile_errors_field(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithFieldE("user", func(v string) (squirrel.Sqlizer, error) {
+		if v != "alice" {
+			return nil, errBroken
+		}
+
+		return squirrel.Eq{"user_id": 1}, nil
+	}))
+
+	_, err := p.Go("user:alice")
+	assert.Nil(t, err)
+
+	_, err = p.Go(`bob and user:"carol"`)
+	assert.True(t, errors.Is(err, errBroken))
+	assert.EqualError(t, err, `can not compile user:"carol" at 1:9: broken`)
+
+	_, err = p.Compile(&TermNode{Field: "user", Value: "bob"})
+	assert.EqualError(t, err, "can not compile user:bob: broken")
+
+	_, err = p.Go("group:admin")
+	var unknownErr *UnknownFieldError
+	assert.True(t, errors.As(err, &unknownErr))
+	assert.Equal(t, []string{"user"}, unknownErr.Allowed)
+}
+
+func Test_compile_errors_not(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		if s == "bad" {
+			return broken{}
+		}
+
+		return squirrel.Expr("col = ?", s)
+	})
+
+	exp, err := p.Go("alice and not (bob or bad)")
+	assert.Nil(t, err)
+
+	_, _, err = exp.ToSql()
+	assert.True(t, errors.Is(err, errBroken))
+	assert.EqualError(t, err, "NOT operand: "+errBroken.Error())
+}
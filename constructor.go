@@ -1,61 +1,43 @@
 package parser
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/Masterminds/squirrel"
 )
 
-// Parser exposes the Go
+// ParserOf exposes the Go, Parse and Compile of a parser compiling into T
+type ParserOf[T any] interface {
+	Go(string) (T, error)
+	Parse(string) (Node, error)
+	Compile(Node) (T, error)
+
+	GoContext(context.Context, string) (T, error)
+	ParseContext(context.Context, string) (Node, error)
+}
+
+// Parser exposes the Go, every ParserOf squirrel like the one returned by
+// New is a Parser
 type Parser interface {
 	Go(string) (squirrel.Sqlizer, error)
 }
 
-// New constructor
-func New(Str func(search string) squirrel.Sqlizer) Parser {
+// New constructor, terms go to Str through StrBuilder unless WithBuilder
+// replaces it. An option given an invalid table, like WithKeywords or
+// WithPrecedence, makes Go, Parse and Compile return its error
+func New(Str func(search string) squirrel.Sqlizer, options ...Option) ParserOf[squirrel.Sqlizer] {
 	p := &parser2{
-		Str: Str,
-		StrORStr: func(a, b string) squirrel.Or {
-			return squirrel.Or{Str(a), Str(b)}
-		},
-
-		ExpORStr: func(a squirrel.Sqlizer, b string) squirrel.Or {
-			return squirrel.Or{a, Str(b)}
-		},
-
-		StrORExp: func(a string, b squirrel.Sqlizer) squirrel.Or {
-			return squirrel.Or{Str(a), b}
-		},
-
-		ExpORExp: func(a, b squirrel.Sqlizer) squirrel.Or {
-			return squirrel.Or{a, b}
-		},
-
-		StrANDStr: func(a, b string) squirrel.And {
-			return squirrel.And{Str(a), Str(b)}
-		},
-
-		ExpANDStr: func(a squirrel.Sqlizer, b string) squirrel.And {
-			return squirrel.And{a, Str(b)}
-		},
-
-		StrANDExp: func(a string, b squirrel.Sqlizer) squirrel.And {
-			return squirrel.And{Str(a), b}
-		},
-
-		ExpANDExp: func(a, b squirrel.Sqlizer) squirrel.And {
-			return squirrel.And{a, b}
-		},
-
-		NotStr: func(a string) squirrel.Sqlizer {
-			s, v, _ := Str(a).ToSql()
-			return squirrel.Expr(fmt.Sprintf("NOT (%s)", s), v...)
-		},
-
-		NotExp: func(a squirrel.Sqlizer) squirrel.Sqlizer {
-			s, v, _ := a.ToSql()
-			return squirrel.Expr(fmt.Sprintf("NOT (%s)", s), v...)
-		},
+		Builder: StrBuilder(Str),
+		Str:     Str,
+		Compare: DefaultCompare,
+		Range:   DefaultRange,
+		In:      DefaultIn,
+
+		syntax: newSyntax(),
+	}
+
+	for _, option := range options {
+		option(p)
 	}
 
 	return p
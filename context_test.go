@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func Test_context_callbacks(t *testing.T) {
+	ids := map[string]int{"alice": 1, "bob": 2}
+
+	p := New(nil,
+		WithStrContext(func(ctx context.Context, s string) (squirrel.Sqlizer, error) {
+			return squirrel.Expr("col = ?", ctx.Value(ctxKey{}).(string)+s), nil
+		}),
+		WithFieldContext("user", func(ctx context.Context, name string) (squirrel.Sqlizer, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			id, ok := ids[name]
+			if !ok {
+				return nil, errors.New("no such user")
+			}
+
+			return squirrel.Eq{"user_id": id}, nil
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant:")
+
+	exp, err := p.GoContext(ctx, "user:alice and not bug")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, "tenant:bug"}, v)
+	assert.Equal(t, "(user_id = ? AND NOT (col = ?))", sql)
+
+	_, err = p.GoContext(ctx, "bug or user:carol")
+	assert.EqualError(t, err, "can not compile user:carol at 1:8: no such user")
+}
+
+func Test_context_canceled(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	input := strings.Repeat("(", 100) + "alice" + strings.Repeat(")", 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.ParseContext(ctx, input)
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = p.GoContext(ctx, input)
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = NewOf[string](prefixBuilder{}).GoContext(ctx, "alice or bob")
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = p.GoContext(context.Background(), input)
+	assert.Nil(t, err)
+}
+
+func Test_context_canceled_while_compiling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	p := New(nil, WithStrContext(func(ctx context.Context, s string) (squirrel.Sqlizer, error) {
+		calls++
+		cancel()
+
+		return squirrel.Expr("col = ?", s), nil
+	}))
+
+	_, err := p.GoContext(ctx, "alice or bob or carol")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Dialect renders the predicates whose SQL differs between databases
+type Dialect interface {
+	// Regex matches column against the regex, or fails with
+	// ErrorRegexUnsupported
+	Regex(column string, r *RegexNode) (squirrel.Sqlizer, error)
+}
+
+// Dialects
+var (
+	PostgreSQL Dialect = postgreSQL{}
+	MySQL      Dialect = mySQL{}
+	SQLite     Dialect = sqlite{}
+	SQLServer  Dialect = sqlServer{}
+)
+
+type postgreSQL struct{}
+
+// Regex renders column ~ pattern, or ~* to ignore case
+func (postgreSQL) Regex(column string, r *RegexNode) (squirrel.Sqlizer, error) {
+	operator := "~"
+	if r.IgnoreCase() {
+		operator = "~*"
+	}
+
+	return squirrel.Expr(fmt.Sprintf("%s %s ?", column, operator), r.Pattern), nil
+}
+
+type mySQL struct{}
+
+// Regex renders column REGEXP pattern, or REGEXP_LIKE with the flag i to
+// ignore case whatever the collation
+func (mySQL) Regex(column string, r *RegexNode) (squirrel.Sqlizer, error) {
+	if r.IgnoreCase() {
+		return squirrel.Expr(fmt.Sprintf("REGEXP_LIKE(%s, ?, 'i')", column), r.Pattern), nil
+	}
+
+	return squirrel.Expr(fmt.Sprintf("%s REGEXP ?", column), r.Pattern), nil
+}
+
+type sqlite struct{}
+
+// Regex renders column REGEXP pattern, prefixing the pattern with (?i) to
+// ignore case as the regexp function registered in SQLite is expected to
+// follow the Go or PCRE syntax
+func (sqlite) Regex(column string, r *RegexNode) (squirrel.Sqlizer, error) {
+	pattern := r.Pattern
+	if r.IgnoreCase() {
+		pattern = "(?i)" + pattern
+	}
+
+	return squirrel.Expr(fmt.Sprintf("%s REGEXP ?", column), pattern), nil
+}
+
+type sqlServer struct{}
+
+// Regex fails since SQL Server has no regex operator
+func (sqlServer) Regex(column string, r *RegexNode) (squirrel.Sqlizer, error) {
+	return nil, fmt.Errorf("%w: SQL Server can not match %s", ErrorRegexUnsupported, r)
+}
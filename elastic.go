@@ -0,0 +1,295 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ElasticQuery is a query of the OpenSearch and Elasticsearch query DSL,
+// encoding/json marshals it into the query of a search request
+type ElasticQuery map[string]interface{}
+
+// ElasticClause is how the terms of a field are matched
+type ElasticClause string
+
+// Clauses of the terms
+const (
+	ElasticMatch ElasticClause = "match" // analyzed text, match_phrase for a phrase
+	ElasticTerm  ElasticClause = "term"  // exact value, terms for a list
+)
+
+// ElasticMapping tells how the terms are matched. A field of Fields is
+// matched by its clause, or by match when its clause is empty, it accepts
+// field:value, comparisons, ranges, lists and, when Regex is set, regexes
+// matched by regexp clauses. Comparisons and ranges become range clauses
+// and wildcards become wildcard clauses. The terms without field are
+// matched on DefaultField, or on every field by multi_match when it is
+// empty
+type ElasticMapping struct {
+	DefaultField string
+	Fields       map[string]ElasticClause
+	Regex        bool
+}
+
+// NewElastic returns a ParserOf compiling expressions into bool queries:
+// and is must, or is should with minimum_should_match 1 and not is
+// must_not. Chains of the same operator are merged into one bool query.
+// It takes the same options as NewOf
+func NewElastic(mapping ElasticMapping, options ...Option) ParserOf[ElasticQuery] {
+	fields := []string{}
+	for field := range mapping.Fields {
+		fields = append(fields, field)
+	}
+
+	options = append([]Option{WithFieldNames(fields...), WithComparisons(fields...)}, options...)
+	if mapping.Regex {
+		options = append(options, WithRegexNames())
+	}
+
+	return NewOf[ElasticQuery](elastic{mapping: mapping}, options...)
+}
+
+// elastic is the LeafBuilder of NewElastic
+type elastic struct {
+	mapping ElasticMapping
+}
+
+func (e elastic) Term(search string) ElasticQuery {
+	return e.match(e.mapping.DefaultField, search, false)
+}
+
+func (e elastic) Phrase(search string) ElasticQuery {
+	return e.match(e.mapping.DefaultField, search, true)
+}
+
+func (e elastic) And(a, b ElasticQuery) ElasticQuery {
+	return ElasticQuery{"bool": ElasticQuery{"must": merge(a, b, "must")}}
+}
+
+func (e elastic) Or(a, b ElasticQuery) ElasticQuery {
+	return ElasticQuery{"bool": ElasticQuery{
+		"should":               merge(a, b, "should"),
+		"minimum_should_match": 1,
+	}}
+}
+
+func (e elastic) Not(a ElasticQuery) ElasticQuery {
+	return ElasticQuery{"bool": ElasticQuery{"must_not": []ElasticQuery{a}}}
+}
+
+func (e elastic) Leaf(n Node) (ElasticQuery, error) {
+	switch n := n.(type) {
+	case *TermNode:
+		field := n.Field
+		if field == "" {
+			field = e.mapping.DefaultField
+		}
+
+		if !n.Wildcard {
+			return e.match(field, n.Value, n.Quoted), nil
+		}
+
+		if field == "" {
+			return nil, ErrorNotDefinedDefaultField
+		}
+
+		return ElasticQuery{"wildcard": ElasticQuery{field: ElasticQuery{"value": n.Value}}}, nil
+	case *ComparisonNode:
+		switch n.Operator {
+		case compareEq:
+			return e.match(n.Field, n.Value, n.Quoted), nil
+		case compareNotEq:
+			return e.Not(e.match(n.Field, n.Value, n.Quoted)), nil
+		}
+
+		bound := map[string]string{
+			compareGt:   "gt",
+			compareGtEq: "gte",
+			compareLt:   "lt",
+			compareLtEq: "lte",
+		}[n.Operator]
+
+		return ElasticQuery{"range": ElasticQuery{n.Field: ElasticQuery{bound: n.Value}}}, nil
+	case *RangeNode:
+		bounds := ElasticQuery{}
+		if n.Lower != "" && n.IncludeLower {
+			bounds["gte"] = n.Lower
+		} else if n.Lower != "" {
+			bounds["gt"] = n.Lower
+		}
+
+		if n.Upper != "" && n.IncludeUpper {
+			bounds["lte"] = n.Upper
+		} else if n.Upper != "" {
+			bounds["lt"] = n.Upper
+		}
+
+		if len(bounds) == 0 {
+			return ElasticQuery{"exists": ElasticQuery{"field": n.Field}}, nil
+		}
+
+		return ElasticQuery{"range": ElasticQuery{n.Field: bounds}}, nil
+	case *InNode:
+		if e.mapping.Fields[n.Field] == ElasticTerm {
+			return ElasticQuery{"terms": ElasticQuery{n.Field: n.Values}}, nil
+		}
+
+		r := e.match(n.Field, n.Values[0], false)
+		for _, value := range n.Values[1:] {
+			r = e.Or(r, e.match(n.Field, value, false))
+		}
+
+		return r, nil
+	case *RegexNode:
+		field := n.Field
+		if field == "" {
+			field = e.mapping.DefaultField
+		}
+
+		if field == "" {
+			return nil, ErrorNotDefinedDefaultField
+		}
+
+		pattern, ok := luceneRegex(n.Pattern)
+		if !ok {
+			return nil, fmt.Errorf("%w: OpenSearch can not match %s", ErrorRegexUnsupported, n)
+		}
+
+		regexp := ElasticQuery{"value": pattern}
+		if n.IgnoreCase() {
+			regexp["case_insensitive"] = true
+		}
+
+		return ElasticQuery{"regexp": ElasticQuery{field: regexp}}, nil
+	}
+
+	return nil, ErrorExpression
+}
+
+// luceneRegex translates pattern into the Lucene syntax of regexp queries,
+// which match the whole value: the anchors ^ and $ of each alternative are
+// dropped and an unanchored side is opened with .*. The characters Lucene
+// reads as operators are escaped. It fails on anchors elsewhere, on the
+// anchors \b, \B, \A and \z and on the groups starting by (?
+func luceneRegex(pattern string) (string, bool) {
+	alternatives := []string{}
+	depth, start, class, escaped := 0, 0, false, false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case class:
+			class = c != ']'
+		case c == '[':
+			class = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '|' && depth == 0:
+			alternatives = append(alternatives, pattern[start:i])
+			start = i + 1
+		}
+	}
+
+	alternatives = append(alternatives, pattern[start:])
+	for i, alternative := range alternatives {
+		translated, ok := luceneAlternative(alternative)
+		if !ok {
+			return "", false
+		}
+
+		alternatives[i] = translated
+	}
+
+	return strings.Join(alternatives, "|"), true
+}
+
+// luceneAlternative translates one alternative of luceneRegex
+func luceneAlternative(s string) (string, bool) {
+	prefix, suffix := ".*", ".*"
+	if strings.HasPrefix(s, "^") {
+		prefix, s = "", s[1:]
+	}
+
+	var b strings.Builder
+	class, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped && strings.IndexByte(regexAnchors, c) >= 0:
+			return "", false
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case class:
+			class = c != ']'
+		case c == '[':
+			class = true
+		case c == '$' && i == len(s)-1:
+			suffix = ""
+			continue
+		case c == '^' || c == '$' || c == '(' && strings.HasPrefix(s[i:], "(?"):
+			return "", false
+		case strings.IndexByte(luceneOperators, c) >= 0:
+			b.WriteByte('\\')
+		}
+
+		b.WriteByte(c)
+	}
+
+	return prefix + b.String() + suffix, true
+}
+
+// match returns the clause of field matching value
+func (e elastic) match(field, value string, phrase bool) ElasticQuery {
+	if field == "" {
+		multiMatch := ElasticQuery{"query": value}
+		if phrase {
+			multiMatch["type"] = "phrase"
+		}
+
+		return ElasticQuery{"multi_match": multiMatch}
+	}
+
+	if e.mapping.Fields[field] == ElasticTerm {
+		return ElasticQuery{"term": ElasticQuery{field: value}}
+	}
+
+	if phrase {
+		return ElasticQuery{"match_phrase": ElasticQuery{field: value}}
+	}
+
+	return ElasticQuery{"match": ElasticQuery{field: value}}
+}
+
+// merge returns the operands of a followed by the ones of b
+func merge(a, b ElasticQuery, occurrence string) []ElasticQuery {
+	r := append([]ElasticQuery{}, operands(a, occurrence)...)
+	return append(r, operands(b, occurrence)...)
+}
+
+// operands returns the operands of q when q is a bool query made only of
+// occurrence, to merge chains of the same operator, otherwise q alone
+func operands(q ElasticQuery, occurrence string) []ElasticQuery {
+	b, ok := q["bool"].(ElasticQuery)
+	if !ok {
+		return []ElasticQuery{q}
+	}
+
+	clauses, ok := b[occurrence].([]ElasticQuery)
+	if !ok {
+		return []ElasticQuery{q}
+	}
+
+	for key := range b {
+		if key != occurrence && key != "minimum_should_match" {
+			return []ElasticQuery{q}
+		}
+	}
+
+	return clauses
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_elastic(t *testing.T) {
+	p := NewElastic(ElasticMapping{
+		DefaultField: "message",
+		Fields: map[string]ElasticClause{
+			"status": ElasticTerm,
+			"title":  ElasticMatch,
+			"price":  "",
+		},
+		Regex: true,
+	})
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"alice",
+			`{"match":{"message":"alice"}}`,
+		},
+		{
+			`alice and "salt and pepper" and not bob`,
+			`{"bool":{"must":[{"match":{"message":"alice"}},{"match_phrase":{"message":"salt and pepper"}},{"bool":{"must_not":[{"match":{"message":"bob"}}]}}]}}`,
+		},
+		{
+			"status:open or status:closed or title:go",
+			`{"bool":{"minimum_should_match":1,"should":[{"term":{"status":"open"}},{"term":{"status":"closed"}},{"match":{"title":"go"}}]}}`,
+		},
+		{
+			"(a and b) or (c and d)",
+			`{"bool":{"minimum_should_match":1,"should":[{"bool":{"must":[{"match":{"message":"a"}},{"match":{"message":"b"}}]}},{"bool":{"must":[{"match":{"message":"c"}},{"match":{"message":"d"}}]}}]}}`,
+		},
+		{
+			"price>=10 and price<20 and status!=closed",
+			`{"bool":{"must":[{"range":{"price":{"gte":"10"}}},{"range":{"price":{"lt":"20"}}},{"bool":{"must_not":[{"term":{"status":"closed"}}]}}]}}`,
+		},
+		{
+			"price:[10 TO *} or price:{* TO *}",
+			`{"bool":{"minimum_should_match":1,"should":[{"range":{"price":{"gte":"10"}}},{"exists":{"field":"price"}}]}}`,
+		},
+		{
+			"status=open or status=new or title:(go, rust)",
+			`{"bool":{"minimum_should_match":1,"should":[{"term":{"status":"open"}},{"term":{"status":"new"}},{"match":{"title":"go"}},{"match":{"title":"rust"}}]}}`,
+		},
+		{
+			"ali* and title:/^go(lang)?$/i",
+			`{"bool":{"must":[{"wildcard":{"message":{"value":"ali*"}}},{"regexp":{"title":{"case_insensitive":true,"value":"go(lang)?"}}}]}}`,
+		},
+	}
+
+	for _, curr := range cases {
+		q, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		body, err := json.Marshal(q)
+		assert.Nil(t, err)
+		assert.Equal(t, curr.expected, string(body), curr.input)
+	}
+}
+
+func Test_elastic_collapse(t *testing.T) {
+	p := NewElastic(ElasticMapping{Fields: map[string]ElasticClause{"status": ElasticTerm}}, WithOptimize(CollapseIn))
+
+	q, err := p.Go("status=open or status=new or status:(closed)")
+	assert.Nil(t, err)
+
+	body, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"terms":{"status":["open","new","closed"]}}`, string(body))
+}
+
+func Test_elastic_regex(t *testing.T) {
+	p := NewElastic(ElasticMapping{DefaultField: "message", Regex: true})
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{`/^go$/`, "go"},
+		{`/go/`, ".*go.*"},
+		{`/^go|rust$/`, "go.*|.*rust"},
+		{`/(go|rust)[$^]\$/`, `.*(go|rust)[$^]\$.*`},
+		{`/a&b<1>/`, `.*a\&b\<1\>.*`},
+	}
+
+	for _, curr := range cases {
+		q, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		assert.Equal(t, ElasticQuery{"regexp": ElasticQuery{"message": ElasticQuery{"value": curr.expected}}}, q, curr.input)
+	}
+
+	for _, input := range []string{`/a^b/`, `/(^a)/`, `/a$b/`, `/(?:a)/`, `/\bgo\b/`, `/\Bgo/`, `/\Ago/`, `/go\z/`} {
+		_, err := p.Go(input)
+		assert.True(t, errors.Is(err, ErrorRegexUnsupported), input)
+	}
+
+	q, err := NewElastic(ElasticMapping{DefaultField: "message"}).Go("/go/")
+	assert.Nil(t, err)
+	assert.Equal(t, ElasticQuery{"match": ElasticQuery{"message": "/go/"}}, q)
+}
+
+func Test_elastic_without_default_field(t *testing.T) {
+	p := NewElastic(ElasticMapping{Fields: map[string]ElasticClause{"tag": ElasticTerm}})
+
+	q, err := p.Go(`alice or "bob smith" or tag:go`)
+	assert.Nil(t, err)
+
+	body, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"bool":{"minimum_should_match":1,"should":[{"multi_match":{"query":"alice"}},{"multi_match":{"query":"bob smith","type":"phrase"}},{"term":{"tag":"go"}}]}}`, string(body))
+
+	_, err = p.Go("tag:go and ali*")
+	assert.True(t, errors.Is(err, ErrorNotDefinedDefaultField))
+	assert.EqualError(t, err, "can not compile ali* at 1:12: not defined DefaultField")
+
+	var unknownErr *UnknownFieldError
+	_, err = p.Go("user:alice")
+	assert.True(t, errors.As(err, &unknownErr))
+}
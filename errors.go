@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError tells where and why an expression can not be parsed.
+// It matches ErrorExpression and the more specific Err via errors.Is
+type SyntaxError struct {
+	Offset   int    // byte offset of Token in the input
+	Line     int    // 1-based line of Token
+	Column   int    // 1-based column of Token, counted in runes
+	Token    string // offending token, empty at the end of input
+	Expected string // what the parser was looking for, e.g. "term after 'and'"
+	Err      error  // ErrorExpression, ErrorOperators or ErrorParentheses
+}
+
+func (e *SyntaxError) Error() string {
+	found := "end of input"
+	if e.Token != "" {
+		found = fmt.Sprintf("'%s'", e.Token)
+	}
+
+	return fmt.Sprintf("%s at %d:%d: expected %s, found %s",
+		e.Err, e.Line, e.Column, e.Expected, found)
+}
+
+// Unwrap returns the sentinel error
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrorExpression for every syntax error
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrorExpression
+}
+
+func newSyntaxError(src string, t token, expected string, err error) *SyntaxError {
+	line, column := position(src, t.pos)
+
+	return &SyntaxError{
+		Offset:   t.pos,
+		Line:     line,
+		Column:   column,
+		Token:    t.text,
+		Expected: expected,
+		Err:      err,
+	}
+}
+
+// position returns the 1-based line and column, in runes, of offset in src
+func position(src string, offset int) (int, int) {
+	before := src[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+
+	return line, column
+}
+
+// CompileError tells a callback rejected a term. The position is known
+// when the term was parsed by Go, Compile alone leaves Offset at -1
+type CompileError struct {
+	Offset int    // byte offset of Term in the input
+	Line   int    // 1-based line of Term
+	Column int    // 1-based column of Term, counted in runes
+	Term   string // the term as written
+	Err    error  // the error returned by the callback
+
+	node Node
+}
+
+func (e *CompileError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("can not compile %s: %s", e.Term, e.Err)
+	}
+
+	return fmt.Sprintf("can not compile %s at %d:%d: %s", e.Term, e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the error of the callback
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
+
+func newCompileError(n Node, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &CompileError{Offset: -1, Term: n.String(), Err: err, node: n}
+}
+
+// locate sets the position of the CompileError in err, if any, from the
+// offsets of the nodes parsed from src
+func locate(err error, src string, offsets map[Node]int) error {
+	var compileErr *CompileError
+	if !errors.As(err, &compileErr) || compileErr.Offset >= 0 {
+		return err
+	}
+
+	if offset, ok := offsets[compileErr.node]; ok {
+		compileErr.Offset = offset
+		compileErr.Line, compileErr.Column = position(src, offset)
+	}
+
+	return err
+}
+
+// UnknownFieldError tells a field:value term names a field without handler
+type UnknownFieldError struct {
+	Field   string
+	Allowed []string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q, allowed fields are %s", e.Field, strings.Join(e.Allowed, ", "))
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_syntax_error_positions(t *testing.T) {
+	cases := []struct {
+		input    string
+		offset   int
+		column   int
+		token    string
+		expected string
+		err      error
+	}{
+		{"alice and and bob", 10, 11, "and", "term after 'and'", ErrorOperators},
+		{"and alice", 0, 1, "and", "term", ErrorOperators},
+		{"alice or", 8, 9, "", "term after 'or'", ErrorOperators},
+		{"alice not bob", 6, 7, "not", "'and' or 'or'", ErrorOperators},
+		{"(alice and bob", 14, 15, "", "')'", ErrorParentheses},
+		{"alice and bob)", 13, 14, ")", "operator or end of input", ErrorParentheses},
+		{"alice and ()", 11, 12, ")", "term after '('", ErrorParentheses},
+		{"alice (bob)", 6, 7, "(", "operator or end of input", ErrorExpression},
+		{"", 0, 1, "", "term", ErrorExpression},
+		{"ñandú and or bob", 12, 11, "or", "term after 'and'", ErrorOperators},
+	}
+
+	for _, curr := range cases {
+		_, err := parse(curr.input)
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), curr.input) {
+			continue
+		}
+
+		assert.Equal(t, curr.offset, syntaxErr.Offset, curr.input)
+		assert.Equal(t, 1, syntaxErr.Line, curr.input)
+		assert.Equal(t, curr.column, syntaxErr.Column, curr.input)
+		assert.Equal(t, curr.token, syntaxErr.Token, curr.input)
+		assert.Equal(t, curr.expected, syntaxErr.Expected, curr.input)
+		assert.True(t, errors.Is(err, curr.err), curr.input)
+		assert.True(t, errors.Is(err, ErrorExpression), curr.input)
+	}
+}
+
+func Test_syntax_error_lines(t *testing.T) {
+	_, err := parse("alice\n and and bob")
+
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 11, syntaxErr.Offset)
+	assert.Equal(t, 2, syntaxErr.Line)
+	assert.Equal(t, 6, syntaxErr.Column)
+	assert.Equal(t, "and", syntaxErr.Token)
+}
+
+func Test_syntax_error_message(t *testing.T) {
+	_, err := parse("alice and and bob")
+	assert.EqualError(t, err, "operator do not match at 1:11: expected term after 'and', found 'and'")
+
+	_, err = parse("(alice")
+	assert.EqualError(t, err, "parentheses do not match at 1:7: expected ')', found end of input")
+}
@@ -0,0 +1,71 @@
+package parser
+
+// Predicate tells if a record matches an expression
+type Predicate[R any] func(record R) bool
+
+// Filter returns the records matching p, in order
+func (p Predicate[R]) Filter(records []R) []R {
+	r := []R{}
+	for _, record := range records {
+		if p(record) {
+			r = append(r, record)
+		}
+	}
+
+	return r
+}
+
+// Matcher tells if record matches the leaf n: a *TermNode or, when
+// enabled by the options, a *ComparisonNode, *RangeNode, *InNode or
+// *RegexNode
+type Matcher[R any] func(record R, n Node) bool
+
+// NewEvaluator returns a ParserOf compiling expressions into predicates
+// tested in memory, the leaves are tested by match. It takes the same
+// options as NewOf
+func NewEvaluator[R any](match Matcher[R], options ...Option) ParserOf[Predicate[R]] {
+	return NewOf[Predicate[R]](evaluator[R]{match: match}, options...)
+}
+
+// evaluator is the LeafBuilder of NewEvaluator
+type evaluator[R any] struct {
+	match Matcher[R]
+}
+
+func (e evaluator[R]) Term(search string) Predicate[R] {
+	n := &TermNode{Value: search}
+	return func(record R) bool {
+		return e.match(record, n)
+	}
+}
+
+func (e evaluator[R]) Phrase(search string) Predicate[R] {
+	n := &TermNode{Value: search, Quoted: true}
+	return func(record R) bool {
+		return e.match(record, n)
+	}
+}
+
+func (e evaluator[R]) Leaf(n Node) (Predicate[R], error) {
+	return func(record R) bool {
+		return e.match(record, n)
+	}, nil
+}
+
+func (evaluator[R]) And(a, b Predicate[R]) Predicate[R] {
+	return func(record R) bool {
+		return a(record) && b(record)
+	}
+}
+
+func (evaluator[R]) Or(a, b Predicate[R]) Predicate[R] {
+	return func(record R) bool {
+		return a(record) || b(record)
+	}
+}
+
+func (evaluator[R]) Not(a Predicate[R]) Predicate[R] {
+	return func(record R) bool {
+		return !a(record)
+	}
+}
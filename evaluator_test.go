@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	Message string
+	Level   string
+	Code    int
+}
+
+func matchEvent(e event, n Node) bool {
+	switch n := n.(type) {
+	case *TermNode:
+		if n.Field == "level" {
+			return e.Level == n.Value
+		}
+
+		if n.Wildcard {
+			pattern := regexp.QuoteMeta(n.Value)
+			pattern = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(pattern)
+			return regexp.MustCompile("^" + pattern + "$").MatchString(e.Message)
+		}
+
+		return strings.Contains(e.Message, n.Value)
+	case *ComparisonNode:
+		value, _ := strconv.Atoi(n.Value)
+		switch n.Operator {
+		case ">=":
+			return e.Code >= value
+		case "<":
+			return e.Code < value
+		}
+
+		return e.Code == value
+	case *InNode:
+		for _, v := range n.Values {
+			if strconv.Itoa(e.Code) == v {
+				return true
+			}
+		}
+	case *RegexNode:
+		return regexp.MustCompile(n.Pattern).MatchString(e.Message)
+	}
+
+	return false
+}
+
+func Test_evaluator(t *testing.T) {
+	events := []event{
+		{"disk full", "error", 507},
+		{"user logged in", "info", 200},
+		{"timeout reading db", "error", 504},
+		{"cache miss", "debug", 404},
+	}
+
+	p := NewEvaluator(matchEvent,
+		WithFieldNames("level"),
+		WithComparisons("code"),
+		WithRegexNames(),
+		WithKeywords(ExtendedKeywords()),
+	)
+
+	cases := []struct {
+		input    string
+		expected []int
+	}{
+		{"level:error", []int{507, 504}},
+		{"level:error and not timeout", []int{507}},
+		{"code>=500 or cache", []int{507, 504, 404}},
+		{"(code=200 or code=404) and not level:debug", []int{200}},
+		{"user* or /^disk/", []int{507, 200}},
+		{"level:error xor code<505", []int{507, 200, 404}},
+		{`"logged in" implies level:info`, []int{507, 200, 504, 404}},
+	}
+
+	for _, curr := range cases {
+		match, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		codes := []int{}
+		for _, e := range match.Filter(events) {
+			codes = append(codes, e.Code)
+		}
+
+		assert.Equal(t, curr.expected, codes, curr.input)
+	}
+}
+
+func Test_evaluator_channel(t *testing.T) {
+	match, err := NewEvaluator(func(s string, n Node) bool {
+		return strings.Contains(s, n.(*TermNode).Value)
+	}).Go("alice and not bob")
+	assert.Nil(t, err)
+
+	in := make(chan string, 3)
+	in <- "alice"
+	in <- "alice and bob"
+	in <- "carol"
+	close(in)
+
+	r := []string{}
+	for s := range in {
+		if match(s) {
+			r = append(r, s)
+		}
+	}
+
+	assert.Equal(t, []string{"alice"}, r)
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFieldsParser() ParserOf[squirrel.Sqlizer] {
+	return New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("body LIKE ?", s)
+	},
+		WithField("status", func(v string) squirrel.Sqlizer {
+			return squirrel.Eq{"status": v}
+		}),
+		WithField("author", func(v string) squirrel.Sqlizer {
+			return squirrel.Eq{"author": v}
+		}),
+	)
+}
+
+func Test_fields(t *testing.T) {
+	p := newFieldsParser()
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"status:open",
+			[]interface{}{"open"},
+			"status = ?",
+		},
+		{
+			"status:open and author:alice",
+			[]interface{}{"open", "alice"},
+			"(status = ? AND author = ?)",
+		},
+		{
+			`author:"alice smith" or not (status:closed and bug)`,
+			[]interface{}{"alice smith", "closed", "bug"},
+			"(author = ? OR NOT ((status = ? AND body LIKE ?)))",
+		},
+		{
+			`(status:'in progress')or(crash report)`,
+			[]interface{}{"in progress", "crash report"},
+			"(status = ? OR body LIKE ?)",
+		},
+		{
+			"meeting at 12:30 or status:a:b",
+			[]interface{}{"meeting at 12:30", "a:b"},
+			"(body LIKE ? OR status = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_fields_tree(t *testing.T) {
+	p := newFieldsParser()
+
+	n, err := p.Parse(`status:open and author:"alice \"al\" smith"`)
+	assert.Nil(t, err)
+
+	expected := &AndNode{
+		Left:  &TermNode{Field: "status", Value: "open"},
+		Right: &TermNode{Field: "author", Value: `alice "al" smith`, Quoted: true},
+	}
+	assert.Equal(t, expected, n)
+	assert.Equal(t, `status:open and author:"alice \"al\" smith"`, n.String())
+}
+
+func Test_fields_unknown(t *testing.T) {
+	p := newFieldsParser()
+
+	_, err := p.Go("status:open and priority:high")
+
+	var fieldErr *UnknownFieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "priority", fieldErr.Field)
+	assert.Equal(t, []string{"author", "status"}, fieldErr.Allowed)
+
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 16, syntaxErr.Offset)
+	assert.Equal(t, "priority:high", syntaxErr.Token)
+	assert.Equal(t, "one of author, status", syntaxErr.Expected)
+	assert.True(t, errors.Is(err, ErrorExpression))
+
+	_, err = p.Compile(&TermNode{Field: "priority", Value: "high"})
+	assert.EqualError(t, err, `unknown field "priority", allowed fields are author, status`)
+}
+
+func Test_fields_errors(t *testing.T) {
+	p := newFieldsParser()
+
+	for _, input := range []string{"status:", "status: open", "status:open bug", "bug status:open"} {
+		_, err := p.Parse(input)
+		assert.True(t, errors.Is(err, ErrorExpression), input)
+	}
+}
+
+func Test_fields_disabled(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("body LIKE ?", s)
+	})
+
+	exp, err := p.Go("status:open or http://example.com")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"status:open", "http://example.com"}, v)
+	assert.Equal(t, "(body LIKE ? OR body LIKE ?)", sql)
+}
+
+func Test_fields_are_not_str_terms(t *testing.T) {
+	ExpANDStrCalled := false
+	ExpANDStr := func(a squirrel.Sqlizer, b string) squirrel.And {
+		assert.Equal(t, squirrel.Eq{"status": "open"}, a)
+		assert.Equal(t, "bug", b)
+
+		ExpANDStrCalled = true
+
+		return squirrel.And{a}
+	}
+
+	p := parser2{
+		ExpANDStr: ExpANDStr,
+		Fields: map[string]FieldHandler{
+			"status": func(v string) squirrel.Sqlizer {
+				return squirrel.Eq{"status": v}
+			},
+		},
+	}
+
+	_, err := p.Compile(&AndNode{
+		Left:  &TermNode{Field: "status", Value: "open"},
+		Right: &TermNode{Value: "bug"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, ExpANDStrCalled)
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flatten(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithFlatten())
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"a or b or c or d",
+			[]interface{}{"a", "b", "c", "d"},
+			"(col = ? OR col = ? OR col = ? OR col = ?)",
+		},
+		{
+			"a and (b and c) and not d",
+			[]interface{}{"a", "b", "c", "d"},
+			"(col = ? AND col = ? AND col = ? AND NOT (col = ?))",
+		},
+		{
+			"a or b and c and d or e",
+			[]interface{}{"a", "b", "c", "d", "e"},
+			"(col = ? OR (col = ? AND col = ? AND col = ?) OR col = ?)",
+		},
+		{
+			"(a or b) and (c or d)",
+			[]interface{}{"a", "b", "c", "d"},
+			"((col = ? OR col = ?) AND (col = ? OR col = ?))",
+		},
+		{
+			"a",
+			[]interface{}{"a"},
+			"col = ?",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_flatten_callbacks(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}
+
+	counts := []int{}
+	p := New(Str, WithORAll(func(operands []squirrel.Sqlizer) squirrel.Or {
+		counts = append(counts, len(operands))
+		return squirrel.Or(operands)
+	}))
+
+	exp, err := p.Go("a or b or c and d and e")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3}, counts)
+
+	sql, _, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "(col = ? OR col = ? OR ((col = ? AND col = ?) AND col = ?))", sql)
+
+	exp, err = p.Go("a or b")
+	assert.Nil(t, err)
+	assert.Equal(t, squirrel.Or{Str("a"), Str("b")}, exp)
+}
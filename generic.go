@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+)
+
+// generic is the ParserOf built by NewOf
+type generic[T any] struct {
+	builder  Builder[T]
+	syntax   *syntax
+	optimize func(n Node) Node
+	err      error
+}
+
+// NewOf returns a ParserOf compiling into T through b. The options
+// configure the syntax and the rewrite applied before compiling. The
+// options setting squirrel callbacks, like WithField, WithCompare,
+// WithRegex with a dialect or WithFlatten, make Go, Parse and Compile
+// fail with ErrorOptionUnsupported, see WithFieldNames and WithRegexNames
+// for their syntax alone. Plain terms go to Term, or to Phrase when quoted and b is a PhraseBuilder, the
+// other leaves go to Leaf when b is a LeafBuilder, a wildcard goes to Term
+// otherwise. Xor, nand, nor and implies are built with And, Or and Not
+func NewOf[T any](b Builder[T], options ...Option) ParserOf[T] {
+	p := &parser2{
+		syntax: newSyntax(),
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	g := &generic[T]{
+		builder:  b,
+		syntax:   p.syntax,
+		optimize: p.Optimize,
+	}
+
+	if option := p.squirrelOption(); option != "" {
+		g.err = fmt.Errorf("%w: %s", ErrorOptionUnsupported, option)
+	}
+
+	if p.err != nil {
+		g.err = p.err
+	}
+
+	return g
+}
+
+func (g *generic[T]) compile(ctx context.Context, n Node) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	switch n := n.(type) {
+	case *OrNode:
+		left, right, err := g.compileBoth(ctx, n.Left, n.Right)
+		if err != nil {
+			return zero, err
+		}
+
+		return g.builder.Or(left, right), nil
+	case *AndNode:
+		left, right, err := g.compileBoth(ctx, n.Left, n.Right)
+		if err != nil {
+			return zero, err
+		}
+
+		return g.builder.And(left, right), nil
+	case *NotNode:
+		operand, err := g.compile(ctx, n.Operand)
+		if err != nil {
+			return zero, err
+		}
+
+		return g.builder.Not(operand), nil
+	case *XorNode:
+		return g.compile(ctx, n.Expand())
+	case *NandNode:
+		return g.compile(ctx, n.Expand())
+	case *NorNode:
+		return g.compile(ctx, n.Expand())
+	case *ImpliesNode:
+		return g.compile(ctx, n.Expand())
+	case *GroupNode:
+		return g.compile(ctx, n.Inner)
+	case *TermNode:
+		if phrase, ok := g.builder.(PhraseBuilder[T]); ok && n.Field == "" && n.Quoted {
+			return phrase.Phrase(n.Value), nil
+		}
+
+		if n.Field == "" && !n.Wildcard {
+			return g.builder.Term(n.Value), nil
+		}
+	}
+
+	if leaf, ok := g.builder.(LeafBuilder[T]); ok {
+		r, err := leaf.Leaf(n)
+		return r, newCompileError(n, err)
+	}
+
+	if t, ok := n.(*TermNode); ok && t.Field == "" {
+		return g.builder.Term(t.Value), nil
+	}
+
+	return zero, ErrorNotDefinedLeaf
+}
+
+func (g *generic[T]) compileBoth(ctx context.Context, left, right Node) (T, T, error) {
+	var zero T
+
+	leftExp, err := g.compile(ctx, left)
+	if err != nil {
+		return zero, zero, err
+	}
+
+	rightExp, err := g.compile(ctx, right)
+	if err != nil {
+		return zero, zero, err
+	}
+
+	return leftExp, rightExp, nil
+}
+
+// Parse returns the tree of s
+func (g *generic[T]) Parse(s string) (Node, error) {
+	return g.ParseContext(context.Background(), s)
+}
+
+// ParseContext is Parse stopping with the error of ctx once ctx is done
+func (g *generic[T]) ParseContext(ctx context.Context, s string) (Node, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	n, _, err := g.syntax.parseOffsets(ctx, s)
+	return n, err
+}
+
+// Compile turns a tree, rewritten by the optimize option when set, into T
+func (g *generic[T]) Compile(n Node) (T, error) {
+	return g.compileContext(context.Background(), n)
+}
+
+func (g *generic[T]) compileContext(ctx context.Context, n Node) (T, error) {
+	if g.err != nil {
+		var zero T
+		return zero, g.err
+	}
+
+	if g.optimize != nil {
+		n = g.optimize(n)
+	}
+
+	return g.compile(ctx, n)
+}
+
+// Go parses s and compiles it
+func (g *generic[T]) Go(s string) (T, error) {
+	return g.GoContext(context.Background(), s)
+}
+
+// GoContext is Go stopping with the error of ctx once ctx is done
+func (g *generic[T]) GoContext(ctx context.Context, s string) (T, error) {
+	var zero T
+	if g.err != nil {
+		return zero, g.err
+	}
+
+	n, offsets, err := g.syntax.parseOffsets(ctx, s)
+	if err != nil {
+		return zero, err
+	}
+
+	r, err := g.compileContext(ctx, n)
+	if err != nil {
+		return zero, locate(err, s, offsets)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+// prefixBuilder writes the expression in prefix notation
+type prefixBuilder struct{}
+
+func (prefixBuilder) Term(search string) string {
+	return fmt.Sprintf("%q", search)
+}
+
+func (prefixBuilder) And(a, b string) string {
+	return "(and " + a + " " + b + ")"
+}
+
+func (prefixBuilder) Or(a, b string) string {
+	return "(or " + a + " " + b + ")"
+}
+
+func (prefixBuilder) Not(a string) string {
+	return "(not " + a + ")"
+}
+
+// prefixLeafBuilder writes the leaves too
+type prefixLeafBuilder struct {
+	prefixBuilder
+}
+
+func (prefixLeafBuilder) Leaf(n Node) (string, error) {
+	switch n := n.(type) {
+	case *ComparisonNode:
+		return fmt.Sprintf("(%s %s %q)", n.Operator, n.Field, n.Value), nil
+	case *InNode:
+		return fmt.Sprintf("(in %s %q)", n.Field, n.Values), nil
+	case *TermNode:
+		return fmt.Sprintf("(like %q)", n.Value), nil
+	}
+
+	return "", fmt.Errorf("unexpected %s", n)
+}
+
+// prefixPhraseBuilder writes the phrases apart
+type prefixPhraseBuilder struct {
+	prefixLeafBuilder
+}
+
+func (prefixPhraseBuilder) Phrase(search string) string {
+	return fmt.Sprintf("(phrase %q)", search)
+}
+
+func Test_generic_builder(t *testing.T) {
+	p := NewOf[string](prefixBuilder{}, WithKeywords(ExtendedKeywords()))
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"alice or bob and not carol",
+			`(or "alice" (and "bob" (not "carol")))`,
+		},
+		{
+			"(alice or bob) and ali*",
+			`(and (or "alice" "bob") "ali*")`,
+		},
+		{
+			"alice xor bob",
+			`(or (and "alice" (not "bob")) (and (not "alice") "bob"))`,
+		},
+	}
+
+	for _, curr := range cases {
+		r, err := p.Go(curr.input)
+		assert.Nil(t, err, curr.input)
+		assert.Equal(t, curr.expected, r, curr.input)
+	}
+}
+
+func Test_generic_leaf_builder(t *testing.T) {
+	p := NewOf[string](prefixLeafBuilder{}, WithComparisons("price", "status"), WithOptimize(CollapseIn))
+
+	r, err := p.Go("(status=open or status=closed) and price>=10 and not ali*")
+	assert.Nil(t, err)
+	assert.Equal(t, `(and (and (in status ["open" "closed"]) (>= price "10")) (not (like "ali*")))`, r)
+
+	_, err = p.Go("price>1 and price:[1 TO 2]")
+	assert.EqualError(t, err, "can not compile price:[1 TO 2] at 1:13: unexpected price:[1 TO 2]")
+
+	_, err = NewOf[string](prefixBuilder{}, WithComparisons("price")).Go("alice or price>10")
+	assert.Equal(t, ErrorNotDefinedLeaf, err)
+}
+
+func Test_generic_phrase_builder(t *testing.T) {
+	input := `alice and "bob smith" and ali*`
+
+	r, err := NewOf[string](prefixLeafBuilder{}).Go(input)
+	assert.Nil(t, err)
+	assert.Equal(t, `(and (and "alice" "bob smith") (like "ali*"))`, r)
+
+	r, err = NewOf[string](prefixPhraseBuilder{}).Go(input)
+	assert.Nil(t, err)
+	assert.Equal(t, `(and (and "alice" (phrase "bob smith")) (like "ali*"))`, r)
+}
+
+func Test_generic_squirrel(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}
+
+	input := "(alice or bob) and not carol"
+
+	expected, err := New(Str).Go(input)
+	assert.Nil(t, err)
+
+	exp, err := NewOf[squirrel.Sqlizer](StrBuilder(Str)).Go(input)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, exp)
+}
+
+func Test_generic_squirrel_options(t *testing.T) {
+	cases := []struct {
+		option Option
+		name   string
+	}{
+		{WithField("status", func(value string) squirrel.Sqlizer { return squirrel.Eq{"status": value} }), "WithField"},
+		{WithCompare(DefaultCompare), "WithCompare"},
+		{WithRegex(PostgreSQL, "message"), "WithRegex"},
+		{WithFlatten(), "WithORAll or WithFlatten"},
+		{WithXor(func(a, b squirrel.Sqlizer) squirrel.Sqlizer { return a }), "WithXor"},
+	}
+
+	for _, curr := range cases {
+		p := NewOf[string](prefixBuilder{}, curr.option)
+
+		_, err := p.Go("alice")
+		assert.True(t, errors.Is(err, ErrorOptionUnsupported), curr.name)
+		assert.EqualError(t, err, "option not supported by NewOf: "+curr.name)
+
+		_, err = p.Parse("alice")
+		assert.True(t, errors.Is(err, ErrorOptionUnsupported), curr.name)
+
+		_, err = p.Compile(&TermNode{Value: "alice"})
+		assert.True(t, errors.Is(err, ErrorOptionUnsupported), curr.name)
+	}
+
+	_, err := NewOf[string](prefixBuilder{}, WithRegexNames(), WithFieldNames("status"), WithOptimize(CollapseIn)).Go("alice")
+	assert.Nil(t, err)
+}
+
+func Test_generic_field_names(t *testing.T) {
+	p := NewOf[string](prefixLeafBuilder{}, WithFieldNames("status", "author"))
+
+	r, err := p.Go("status:open and not author:bob")
+	assert.Nil(t, err)
+	assert.Equal(t, `(and (like "open") (not (like "bob")))`, r)
+
+	_, err = New(nil, WithFieldNames("status")).Go("status:open")
+	var unknownErr *UnknownFieldError
+	assert.True(t, errors.As(err, &unknownErr))
+}
+
+// goOnly is a Parser implemented outside the package, like a test mock
+type goOnly struct{}
+
+func (goOnly) Go(string) (squirrel.Sqlizer, error) {
+	return squirrel.Expr("TRUE"), nil
+}
+
+func Test_parser_go_only(t *testing.T) {
+	parsers := []Parser{goOnly{}, New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})}
+
+	for _, p := range parsers {
+		_, err := p.Go("alice")
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,368 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+/*
+	Grammar:
+		expression := or [ "implies" expression ]
+		or         := xor { ( "or" | "nor" ) xor }
+		xor        := and { "xor" and }
+		and        := unary { ( "and" | "nand" ) unary }
+		unary      := "not" unary | primary
+		primary    := "(" expression ")" | field | comparison | range | list | regex | phrase | term { term }
+		field      := name ":" ( word | phrase )
+		comparison := name ( "=" | "!=" | ">" | ">=" | "<" | "<=" ) ( word | phrase )
+		range      := name ":" ( "[" | "{" ) bound "TO" bound ( "]" | "}" )
+		list       := name ":" "(" value { "," value } ")"
+		regex      := [ name ":" ] "/" pattern "/" [ "i" ]
+
+	Adjacent words are one term joined by a single separator, so
+	"alice \t bob" is the term "alice bob". With implicit and, adjacent
+	unary are joined by and instead, so "alice bob" is alice and bob.
+	A phrase is quoted, so "'salt and pepper'" is the term "salt and pepper".
+	A term that is not quoted is a wildcard when it contains * or ?.
+	A field term, a comparison, a range, a list or a regex stands on its own and is only recognized
+	when fields or comparisons are enabled, naming a field that is not
+	enabled is an error.
+	This is StandardPrecedence, with another Precedence the binary
+	operators are grouped into other levels.
+	Chains are built left-deep: "a or b or c" is (a | b) | c, except
+	implies which is built right-deep: "a implies b implies c" is
+	a -> (b -> c). The operators xor, nand, nor and implies have no
+	keyword unless enabled, see ExtendedKeywords.
+*/
+
+// descent keeps the state of the recursive-descent parser, the offsets
+// of the leaves it builds and what it counts for the limits
+type descent struct {
+	ctx     context.Context
+	syntax  *syntax
+	src     string
+	tokens  []token
+	pos     int
+	offsets map[Node]int
+
+	terms     int
+	operators int
+	depth     int
+}
+
+func parse(s string) (Node, error) {
+	return standard.parse(s)
+}
+
+func (sx *syntax) parse(s string) (Node, error) {
+	n, _, err := sx.parseOffsets(context.Background(), s)
+	return n, err
+}
+
+// parseOffsets parses s and returns the offsets of the leaves in s too.
+// It stops with the error of ctx once ctx is done
+func (sx *syntax) parseOffsets(ctx context.Context, s string) (Node, map[Node]int, error) {
+	if err := sx.limits.check(limitLength, len(s)); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := sx.tokenize(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := &descent{
+		ctx:     ctx,
+		syntax:  sx,
+		src:     s,
+		tokens:  tokens,
+		offsets: map[Node]int{},
+	}
+
+	n, err := d.expression()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t := d.peek()
+	switch t.kind {
+	case tokenEOF:
+		return n, d.offsets, nil
+	case tokenClose:
+		return nil, nil, d.fail(t, "operator or end of input", ErrorParentheses)
+	case tokenNot:
+		expected := fmt.Sprintf("'%s' or '%s'", d.syntax.name(tokenAnd), d.syntax.name(tokenOr))
+		return nil, nil, d.fail(t, expected, ErrorOperators)
+	}
+
+	return nil, nil, d.fail(t, "operator or end of input", ErrorExpression)
+}
+
+func (d *descent) field(t token) (Node, error) {
+	known := d.syntax.fields
+	if t.operator != fieldSeparator || t.leaf != nil {
+		known = d.syntax.comparisons
+	}
+
+	if t.kind == tokenList {
+		known = listFields(d.syntax)
+	}
+
+	if !known[t.field] {
+		err := &UnknownFieldError{
+			Field:   t.field,
+			Allowed: fieldNames(known),
+		}
+
+		return nil, d.fail(t, "one of "+strings.Join(err.Allowed, ", "), err)
+	}
+
+	if t.leaf != nil {
+		return t.leaf, nil
+	}
+
+	quoted := t.kind == tokenPhrase
+	if t.value == "" && !quoted {
+		return nil, d.fail(t, fmt.Sprintf("value after '%s'", t.text), ErrorExpression)
+	}
+
+	if t.operator != fieldSeparator {
+		// price<>3 or price==3 is a misspelled operator, not a value
+		if !quoted && strings.ContainsAny(t.value[:1], "<>=!") {
+			return nil, d.fail(t, fmt.Sprintf("value after '%s%s'", t.field, t.operator), ErrorExpression)
+		}
+
+		return &ComparisonNode{Field: t.field, Operator: t.operator, Value: t.value, Quoted: quoted}, nil
+	}
+
+	return &TermNode{Field: t.field, Value: t.value, Quoted: quoted, Wildcard: !quoted && isWildcard(t.value)}, nil
+}
+
+func (d *descent) fail(t token, expected string, err error) error {
+	return newSyntaxError(d.src, t, expected, err)
+}
+
+func (d *descent) peek() token {
+	return d.tokens[d.pos]
+}
+
+func (d *descent) next() token {
+	t := d.tokens[d.pos]
+	if t.kind != tokenEOF {
+		d.pos++
+	}
+
+	return t
+}
+
+// level is a set of binary operators sharing a precedence, see Level
+type level struct {
+	kinds []tokenKind
+	right bool
+}
+
+func (d *descent) expression() (Node, error) {
+	return d.binary(0)
+}
+
+// binary parses the operators of levels[i] and tighter ones
+func (d *descent) binary(i int) (Node, error) {
+	levels := d.syntax.levels
+	if i == len(levels) {
+		return d.unary()
+	}
+
+	left, err := d.binary(i + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		kind, ok := d.operator(levels[i])
+		if !ok {
+			return left, nil
+		}
+
+		d.operators++
+		if err := d.syntax.limits.check(limitOperators, d.operators); err != nil {
+			return nil, err
+		}
+
+		if levels[i].right {
+			right, err := d.nested(func() (Node, error) { return d.binary(i) })
+			if err != nil {
+				return nil, err
+			}
+
+			return newBinary(kind, left, right), nil
+		}
+
+		right, err := d.binary(i + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = newBinary(kind, left, right)
+	}
+}
+
+// operator consumes the next token when it is an operator of l. An operand
+// right after an operand is an implicit and when enabled
+func (d *descent) operator(l level) (tokenKind, bool) {
+	kind := d.peek().kind
+	for _, k := range l.kinds {
+		if k == kind {
+			d.next()
+			return kind, true
+		}
+
+		if k == tokenAnd && d.syntax.implicitAnd && kind.isOperand() {
+			return tokenAnd, true
+		}
+	}
+
+	return tokenEOF, false
+}
+
+func newBinary(kind tokenKind, left, right Node) Node {
+	switch kind {
+	case tokenOr:
+		return &OrNode{Left: left, Right: right}
+	case tokenXor:
+		return &XorNode{Left: left, Right: right}
+	case tokenNand:
+		return &NandNode{Left: left, Right: right}
+	case tokenNor:
+		return &NorNode{Left: left, Right: right}
+	case tokenImplies:
+		return &ImpliesNode{Left: left, Right: right}
+	}
+
+	return &AndNode{Left: left, Right: right}
+}
+
+// nested parses with parse one level deeper, the level of a group or a not
+func (d *descent) nested(parse func() (Node, error)) (Node, error) {
+	d.depth++
+	defer func() { d.depth-- }()
+
+	if err := d.syntax.limits.check(limitDepth, d.depth); err != nil {
+		return nil, err
+	}
+
+	return parse()
+}
+
+func (d *descent) unary() (Node, error) {
+	if err := d.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if d.peek().kind != tokenNot {
+		return d.primary()
+	}
+
+	d.next()
+
+	d.operators++
+	if err := d.syntax.limits.check(limitOperators, d.operators); err != nil {
+		return nil, err
+	}
+
+	operand, err := d.nested(d.unary)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotNode{Operand: operand}, nil
+}
+
+func (d *descent) primary() (Node, error) {
+	expected := "term"
+
+	var prev token
+	if d.pos > 0 {
+		prev = d.tokens[d.pos-1]
+		expected = fmt.Sprintf("term after '%s'", prev.text)
+	}
+
+	t := d.next()
+
+	n, err := d.operand(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != nil {
+		d.offsets[n] = t.pos
+
+		if _, ok := n.(*GroupNode); !ok {
+			d.terms++
+			if err := d.syntax.limits.check(limitTerms, d.terms); err != nil {
+				return nil, err
+			}
+		}
+
+		return n, nil
+	}
+
+	switch {
+	case prev.kind.isOperator() || t.kind.isOperator():
+		return nil, d.fail(t, expected, ErrorOperators)
+	case t.kind == tokenClose:
+		return nil, d.fail(t, expected, ErrorParentheses)
+	}
+
+	return nil, d.fail(t, expected, ErrorExpression)
+}
+
+// operand returns the group or the leaf starting with t, nil when t
+// starts neither
+func (d *descent) operand(t token) (Node, error) {
+	switch t.kind {
+	case tokenOpen:
+		n, err := d.nested(d.expression)
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := d.next(); closing.kind != tokenClose {
+			return nil, d.fail(closing, "')'", ErrorParentheses)
+		}
+
+		return &GroupNode{Inner: n}, nil
+
+	case tokenTerm:
+		if t.field != "" {
+			return d.field(t)
+		}
+
+		words := []string{t.value}
+		for next := d.peek(); !d.syntax.implicitAnd && next.kind == tokenTerm && next.field == ""; next = d.peek() {
+			words = append(words, d.next().value)
+		}
+
+		value := strings.Join(words, separator)
+		return &TermNode{Value: value, Wildcard: isWildcard(value)}, nil
+
+	case tokenPhrase:
+		if t.field != "" {
+			return d.field(t)
+		}
+
+		return &TermNode{Value: t.value, Quoted: true}, nil
+
+	case tokenRange, tokenList:
+		return d.field(t)
+
+	case tokenRegex:
+		if t.field != "" {
+			return d.field(t)
+		}
+
+		return t.leaf, nil
+	}
+
+	return nil, nil
+}
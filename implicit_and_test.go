@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_implicit_and(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithImplicitAnd(), WithSymbols())
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"alice bob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"alice bob or carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"((col = ? AND col = ?) OR col = ?)",
+		},
+		{
+			`"alice bob" carol`,
+			[]interface{}{"alice bob", "carol"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"alice not bob (carol or dave)",
+			[]interface{}{"alice", "bob", "carol", "dave"},
+			"((col = ? AND NOT (col = ?)) AND (col = ? OR col = ?))",
+		},
+		{
+			"alice -bob and carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"((col = ? AND NOT (col = ?)) AND col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_implicit_and_tree(t *testing.T) {
+	p := New(nil, WithImplicitAnd(), WithKeywords(Keywords{
+		And: []string{"y"},
+		Or:  []string{"o"},
+		Not: []string{"no"},
+	}))
+
+	n, err := p.Parse("alice bob y carol")
+	assert.Nil(t, err)
+
+	expected := &AndNode{
+		Left: &AndNode{
+			Left:  &TermNode{Value: "alice"},
+			Right: &TermNode{Value: "bob"},
+		},
+		Right: &TermNode{Value: "carol"},
+	}
+	assert.Equal(t, expected, n)
+}
+
+func Test_implicit_and_errors(t *testing.T) {
+	p := New(nil, WithImplicitAnd())
+
+	for _, input := range []string{"alice bob or", "alice (bob", "alice bob)", "alice not"} {
+		_, err := p.Parse(input)
+		assert.True(t, errors.Is(err, ErrorExpression), input)
+	}
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Keywords lists the spellings of each operator. Spellings are single
+// words of any script and are matched ignoring case, so a table with
+// Not: []string{"не"} recognizes "НЕ" too. An operator without
+// spellings can not be written
+type Keywords struct {
+	And     []string
+	Or      []string
+	Not     []string
+	Xor     []string
+	Nand    []string
+	Nor     []string
+	Implies []string
+}
+
+// DefaultKeywords returns the keywords and, or and not
+func DefaultKeywords() Keywords {
+	return Keywords{
+		And: []string{operatorAnd},
+		Or:  []string{operatorOr},
+		Not: []string{operatorNot},
+	}
+}
+
+// ExtendedKeywords returns DefaultKeywords plus xor, nand, nor and implies
+func ExtendedKeywords() Keywords {
+	k := DefaultKeywords()
+	k.Xor = []string{operatorXor}
+	k.Nand = []string{operatorNand}
+	k.Nor = []string{operatorNor}
+	k.Implies = []string{operatorImplies}
+
+	return k
+}
+
+// Validate tells if every spelling is a single word owned by one operator
+func (k Keywords) Validate() error {
+	_, err := k.table()
+	return err
+}
+
+// operatorSpellings pairs an operator with its spellings
+type operatorSpellings struct {
+	kind      tokenKind
+	spellings []string
+}
+
+func (k Keywords) operators() []operatorSpellings {
+	return []operatorSpellings{
+		{tokenAnd, k.And},
+		{tokenOr, k.Or},
+		{tokenNot, k.Not},
+		{tokenXor, k.Xor},
+		{tokenNand, k.Nand},
+		{tokenNor, k.Nor},
+		{tokenImplies, k.Implies},
+	}
+}
+
+func (k Keywords) table() (map[string]tokenKind, error) {
+	table := map[string]tokenKind{}
+	for _, operator := range k.operators() {
+		for _, spelling := range operator.spellings {
+			if !isKeyword(spelling) {
+				return nil, fmt.Errorf("%w: %q is not a single word", ErrorKeywordInvalid, spelling)
+			}
+
+			word := strings.ToLower(spelling)
+			if kind, ok := table[word]; ok && kind != operator.kind {
+				return nil, fmt.Errorf("%w: %q is used by %s and %s", ErrorKeywordConflict, spelling, kind, operator.kind)
+			}
+
+			table[word] = operator.kind
+		}
+	}
+
+	return table, nil
+}
+
+func (k Keywords) syntax() (*syntax, error) {
+	table, err := k.table()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[tokenKind]string{}
+	for _, operator := range k.operators() {
+		if len(operator.spellings) > 0 {
+			names[operator.kind] = operator.spellings[0]
+		}
+	}
+
+	levels, _ := StandardPrecedence().levels()
+	return &syntax{keywords: table, names: names, levels: levels}, nil
+}
+
+func isKeyword(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	return strings.IndexFunc(s, unicode.IsSpace) < 0 &&
+		!strings.ContainsAny(s, openExp+closeExp+quoteDouble+quoteSingle)
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_keywords_localized(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}
+
+	cases := []struct {
+		keywords Keywords
+		input    string
+		values   []interface{}
+		sql      string
+	}{
+		{
+			Keywords{And: []string{"y"}, Or: []string{"o"}, Not: []string{"no"}},
+			"alicia y no bob o carolina",
+			[]interface{}{"alicia", "bob", "carolina"},
+			"((col = ? AND NOT (col = ?)) OR col = ?)",
+		},
+		{
+			Keywords{And: []string{"und"}, Or: []string{"oder"}, Not: []string{"nicht"}},
+			"Nicht (alice ODER bob) und carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"(NOT ((col = ? OR col = ?)) AND col = ?)",
+		},
+		{
+			Keywords{And: []string{"и"}, Or: []string{"или"}, Not: []string{"не"}},
+			"НЕ алиса И боб ИЛИ карл",
+			[]interface{}{"алиса", "боб", "карл"},
+			"((NOT (col = ?) AND col = ?) OR col = ?)",
+		},
+		{
+			Keywords{And: []string{"and", "и"}, Or: []string{"or", "или"}, Not: []string{"not", "не"}},
+			"alice и bob or не carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"((col = ? AND col = ?) OR NOT (col = ?))",
+		},
+		{
+			Keywords{And: []string{"y"}, Or: []string{"o"}, Not: []string{"no"}},
+			"alice and bob",
+			[]interface{}{"alice and bob"},
+			"col = ?",
+		},
+	}
+
+	for _, curr := range cases {
+		p := New(Str, WithKeywords(curr.keywords))
+
+		exp, err := p.Go(curr.input)
+		assert.Nil(t, err, curr.input)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_keywords_with_symbols(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithSymbols(), WithKeywords(Keywords{And: []string{"und"}}))
+
+	exp, err := p.Go("alice und bob || !carol")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice", "bob", "carol"}, v)
+	assert.Equal(t, "((col = ? AND col = ?) OR NOT (col = ?))", sql)
+
+	_, err = p.Parse("alice bob")
+	assert.Nil(t, err)
+
+	_, err = p.Parse("alice ! bob")
+	assert.EqualError(t, err, "operator do not match at 1:7: expected 'und' or '||', found '!'")
+}
+
+func Test_keywords_validate(t *testing.T) {
+	assert.Nil(t, DefaultKeywords().Validate())
+	assert.Nil(t, Keywords{And: []string{"y", "Y"}, Or: []string{"o"}}.Validate())
+
+	cases := []struct {
+		keywords Keywords
+		err      error
+		message  string
+	}{
+		{
+			Keywords{And: []string{"y"}, Or: []string{"o"}, Not: []string{"Y"}},
+			ErrorKeywordConflict,
+			`keyword used by two operators: "Y" is used by and and not`,
+		},
+		{
+			Keywords{And: []string{"и"}, Or: []string{"И"}},
+			ErrorKeywordConflict,
+			`keyword used by two operators: "И" is used by and and or`,
+		},
+		{
+			Keywords{And: []string{"and also"}},
+			ErrorKeywordInvalid,
+			`invalid keyword: "and also" is not a single word`,
+		},
+		{
+			Keywords{Or: []string{""}},
+			ErrorKeywordInvalid,
+			`invalid keyword: "" is not a single word`,
+		},
+		{
+			Keywords{Not: []string{"no("}},
+			ErrorKeywordInvalid,
+			`invalid keyword: "no(" is not a single word`,
+		},
+	}
+
+	for _, curr := range cases {
+		err := curr.keywords.Validate()
+		assert.True(t, errors.Is(err, curr.err))
+		assert.EqualError(t, err, curr.message)
+
+		_, err = New(nil, WithKeywords(curr.keywords)).Go("alice")
+		assert.True(t, errors.Is(err, curr.err))
+		assert.EqualError(t, err, curr.message)
+
+		_, err = NewOf[string](prefixBuilder{}, WithKeywords(curr.keywords)).Parse("alice")
+		assert.EqualError(t, err, curr.message)
+	}
+}
@@ -0,0 +1,566 @@
+package parser
+
+import (
+	"fmt"
+	regexsyntax "regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind defines the kind of a token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+	tokenPhrase
+	tokenRange
+	tokenList
+	tokenRegex
+	tokenXor
+	tokenNand
+	tokenNor
+	tokenImplies
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:     "end of input",
+	tokenTerm:    "term",
+	tokenAnd:     "and",
+	tokenOr:      "or",
+	tokenNot:     "not",
+	tokenOpen:    "(",
+	tokenClose:   ")",
+	tokenPhrase:  "phrase",
+	tokenRange:   "range",
+	tokenList:    "list",
+	tokenRegex:   "regex",
+	tokenXor:     "xor",
+	tokenNand:    "nand",
+	tokenNor:     "nor",
+	tokenImplies: "implies",
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+func (k tokenKind) isOperator() bool {
+	switch k {
+	case tokenAnd, tokenOr, tokenNot, tokenXor, tokenNand, tokenNor, tokenImplies:
+		return true
+	}
+
+	return false
+}
+
+// isOperand tells if a token of kind k starts an operand of and
+func (k tokenKind) isOperand() bool {
+	switch k {
+	case tokenTerm, tokenPhrase, tokenRange, tokenList, tokenRegex, tokenOpen, tokenNot:
+		return true
+	}
+
+	return false
+}
+
+// token is a lexeme found in the input at [pos, end). The text is the
+// lexeme as written and the value is the text without field, operator,
+// quotes and escapes. A range or a list is parsed by the tokenizer into leaf
+type token struct {
+	kind     tokenKind
+	text     string
+	field    string
+	operator string
+	value    string
+	leaf     Node
+	pos      int
+	end      int
+}
+
+// syntax holds what the tokenizer recognizes as operators
+type syntax struct {
+	keywords    map[string]tokenKind
+	names       map[tokenKind]string
+	symbols     bool
+	fields      map[string]bool
+	comparisons map[string]bool
+	regex       bool
+	implicitAnd bool
+	levels      []level
+	limits      Limits
+}
+
+func newSyntax() *syntax {
+	sx, _ := DefaultKeywords().syntax()
+	return sx
+}
+
+// name returns how the operator is written in messages
+func (sx *syntax) name(kind tokenKind) string {
+	if name, ok := sx.names[kind]; ok {
+		return name
+	}
+
+	if sx.symbols {
+		switch kind {
+		case tokenAnd:
+			return symbolAnd + symbolAnd
+		case tokenOr:
+			return symbolOr + symbolOr
+		case tokenNot:
+			return symbolNot
+		}
+	}
+
+	return kind.String()
+}
+
+// standard is the syntax used by a parser2 built without New
+var standard = newSyntax()
+
+// tokenize splits s into tokens. Parentheses are always tokens on their own,
+// words are separated by any unicode whitespace and a word is an operator
+// only when it matches a keyword as a whole ignoring case, so "oregon" or
+// "android" remain terms. A word starting with a quote is a phrase running
+// up to the matching quote. When fields are enabled a word prefixed by a
+// field name and a colon, like status:open or title:"a b", names its field.
+// When comparisons are enabled a word like price>=10 or name!="a b" is a
+// comparison, price:[10 TO 20] or price:{10 TO *] is a range and
+// status:(open, "in progress") is a list. When regexes are enabled a word
+// like /err(or)?/i or message:/^panic/ is a regex.
+// When symbols are enabled && and || are operators wherever they appear,
+// & and | only when they stand alone, while ! and - negate only at word
+// start.
+func (sx *syntax) tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	start := -1
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		word := s[start:end]
+		kind, ok := sx.keywords[strings.ToLower(word)]
+		if !ok {
+			kind = tokenTerm
+		}
+
+		t := token{kind: kind, text: word, value: word, pos: start, end: end}
+		if field, operator, value, ok := sx.field(word); ok && kind == tokenTerm {
+			t.field = field
+			t.operator = operator
+			t.value = value
+		}
+
+		tokens = append(tokens, t)
+		start = -1
+	}
+
+	for i := 0; i < len(s); i++ {
+		t := s[i : i+1]
+
+		if r, size := utf8.DecodeRuneInString(s[i:]); unicode.IsSpace(r) {
+			flush(i)
+			i += size - 1
+			continue
+		}
+
+		if sx.symbols {
+			if kind, ok := sx.symbol(s, i, start < 0); ok {
+				flush(i)
+
+				end := i + 1
+				if kind != tokenNot && strings.HasPrefix(s[end:], t) {
+					end++
+				}
+
+				tokens = append(tokens, token{kind: kind, text: s[i:end], value: s[i:end], pos: i, end: end})
+				i = end - 1
+				continue
+			}
+		}
+
+		switch t {
+		case openExp:
+			if start >= 0 {
+				if field, ok := sx.listField(s[start:i]); ok {
+					list, end, err := scanList(s, i)
+					if err != nil {
+						return nil, err
+					}
+
+					list.Field = field
+					tokens = append(tokens, token{kind: tokenList, text: s[start:end], field: field, leaf: list, pos: start, end: end})
+					start = -1
+					i = end - 1
+					continue
+				}
+			}
+
+			flush(i)
+			tokens = append(tokens, token{kind: tokenOpen, text: t, pos: i, end: i + 1})
+		case closeExp:
+			flush(i)
+			tokens = append(tokens, token{kind: tokenClose, text: t, pos: i, end: i + 1})
+		case quoteDouble, quoteSingle:
+			pos, field, operator := i, "", ""
+			if start >= 0 {
+				name, op, rest, ok := sx.field(s[start:i])
+				if !ok || rest != "" {
+					continue
+				}
+
+				pos, field, operator = start, name, op
+				start = -1
+			}
+
+			value, end, ok := unquote(s, i)
+			if !ok {
+				return nil, newSyntaxError(s, token{text: t, pos: i}, fmt.Sprintf("closing %s", t), ErrorQuotes)
+			}
+
+			tokens = append(tokens, token{
+				kind:     tokenPhrase,
+				text:     s[pos:end],
+				field:    field,
+				operator: operator,
+				value:    value,
+				pos:      pos,
+				end:      end,
+			})
+			i = end - 1
+		case rangeInclusiveOpen, rangeExclusiveOpen:
+			if start < 0 {
+				start = i
+				continue
+			}
+
+			field, ok := sx.columnField(s[start:i])
+			if !ok {
+				continue
+			}
+
+			bounds, end, err := scanRange(s, i)
+			if err != nil {
+				return nil, err
+			}
+
+			bounds.Field = field
+			tokens = append(tokens, token{kind: tokenRange, text: s[start:end], field: field, leaf: bounds, pos: start, end: end})
+			start = -1
+			i = end - 1
+		case regexDelimiter:
+			pos, field := i, ""
+			if start >= 0 {
+				name, ok := sx.columnField(s[start:i])
+				if !ok || !sx.regex {
+					continue
+				}
+
+				pos, field = start, name
+			} else if !sx.regex {
+				start = i
+				continue
+			}
+
+			regex, end, err := scanRegex(s, i)
+			if err != nil {
+				return nil, err
+			}
+
+			regex.Field = field
+			tokens = append(tokens, token{kind: tokenRegex, text: s[pos:end], field: field, leaf: regex, pos: pos, end: end})
+			start = -1
+			i = end - 1
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+
+	flush(len(s))
+
+	return append(tokens, token{kind: tokenEOF, pos: len(s), end: len(s)}), nil
+}
+
+// fieldNames returns the names in fields sorted
+func fieldNames(fields map[string]bool) []string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// listFields returns the fields a list like field:(a, b) can be written on,
+// those enabled by fields or by comparisons
+func listFields(sx *syntax) map[string]bool {
+	known := map[string]bool{}
+	for name := range sx.fields {
+		known[name] = true
+	}
+
+	for name := range sx.comparisons {
+		known[name] = true
+	}
+
+	return known
+}
+
+// field splits a word written as field:value when fields are enabled or
+// as field, comparison operator and value when comparisons are enabled
+func (sx *syntax) field(word string) (string, string, string, bool) {
+	i := strings.IndexAny(word, fieldSeparator+"<>=!")
+	if i < 0 || !isFieldName(word[:i]) {
+		return "", "", "", false
+	}
+
+	if word[i:i+1] == fieldSeparator {
+		return word[:i], fieldSeparator, word[i+1:], sx.fields != nil
+	}
+
+	for _, operator := range comparisons {
+		if strings.HasPrefix(word[i:], operator) {
+			return word[:i], operator, word[i+len(operator):], sx.comparisons != nil
+		}
+	}
+
+	return "", "", "", false
+}
+
+// isFieldName tells if s starts by a letter or _ followed by letters,
+// digits, _, . or -
+func isFieldName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '.' || r == '-'):
+		default:
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// symbol tells the operator written as a symbol at s[i]. A single & or |
+// is an operator only when it stands alone, so AT&T remains a term
+func (sx *syntax) symbol(s string, i int, wordStart bool) (tokenKind, bool) {
+	next, _ := utf8.DecodeRuneInString(s[i+1:])
+	alone := wordStart && (next == utf8.RuneError || unicode.IsSpace(next) || next == '(' || next == ')')
+
+	switch s[i : i+1] {
+	case symbolAnd:
+		return tokenAnd, alone || strings.HasPrefix(s[i+1:], symbolAnd)
+	case symbolOr:
+		return tokenOr, alone || strings.HasPrefix(s[i+1:], symbolOr)
+	case symbolNot:
+		return tokenNot, wordStart
+	case symbolMinus:
+		return tokenNot, wordStart && next != utf8.RuneError && !unicode.IsSpace(next)
+	}
+
+	return tokenEOF, false
+}
+
+// columnField tells the field of a word written as field: when
+// comparisons are enabled
+func (sx *syntax) columnField(word string) (string, bool) {
+	field := strings.TrimSuffix(word, fieldSeparator)
+	return field, sx.comparisons != nil && field != word && isFieldName(field)
+}
+
+// listField tells the field of a word written as field: ahead of a list,
+// which is read when fields or comparisons are enabled
+func (sx *syntax) listField(word string) (string, bool) {
+	field := strings.TrimSuffix(word, fieldSeparator)
+	return field, (sx.fields != nil || sx.comparisons != nil) && field != word && isFieldName(field)
+}
+
+// scanRange reads the bounds of the range opened by the bracket at s[start]
+// and returns them with the offset right after the closing bracket
+func scanRange(s string, start int) (*RangeNode, int, error) {
+	opening := token{text: s[start : start+1], pos: start}
+
+	end := strings.IndexAny(s[start:], rangeInclusiveClose+rangeExclusiveClose)
+	if end < 0 {
+		expected := fmt.Sprintf("closing %s or %s", rangeInclusiveClose, rangeExclusiveClose)
+		return nil, 0, newSyntaxError(s, opening, expected, ErrorRange)
+	}
+
+	end += start + 1
+	bounds := strings.Fields(s[start+1 : end-1])
+	if len(bounds) != 3 || !strings.EqualFold(bounds[1], rangeTo) {
+		return nil, 0, newSyntaxError(s, opening, "lower "+rangeTo+" upper", ErrorRange)
+	}
+
+	r := &RangeNode{
+		Lower:        bounds[0],
+		Upper:        bounds[2],
+		IncludeLower: s[start:start+1] == rangeInclusiveOpen,
+		IncludeUpper: s[end-1:end] == rangeInclusiveClose,
+	}
+
+	if r.Lower == rangeOpenBound {
+		r.Lower = ""
+	}
+
+	if r.Upper == rangeOpenBound {
+		r.Upper = ""
+	}
+
+	return r, end, nil
+}
+
+// scanList reads the values of the list opened at s[start] and returns
+// them with the offset right after the closing parenthesis
+func scanList(s string, start int) (*InNode, int, error) {
+	list := &InNode{}
+	i := skipSpace(s, start+1)
+
+	for i < len(s) {
+		item := token{text: s[i : i+1], pos: i}
+
+		switch item.text {
+		case quoteDouble, quoteSingle:
+			value, end, ok := unquote(s, i)
+			if !ok {
+				return nil, 0, newSyntaxError(s, item, fmt.Sprintf("closing %s", item.text), ErrorQuotes)
+			}
+
+			list.Values = append(list.Values, value)
+			i = end
+		case listSeparator, closeExp:
+			return nil, 0, newSyntaxError(s, item, "value", ErrorList)
+		default:
+			end := strings.IndexAny(s[i:], listSeparator+closeExp)
+			if end < 0 {
+				end = len(s) - i
+			}
+
+			list.Values = append(list.Values, strings.TrimRightFunc(s[i:i+end], unicode.IsSpace))
+			i += end
+		}
+
+		i = skipSpace(s, i)
+		if i == len(s) {
+			break
+		}
+
+		switch s[i : i+1] {
+		case closeExp:
+			return list, i + 1, nil
+		case listSeparator:
+			i = skipSpace(s, i+1)
+		default:
+			return nil, 0, newSyntaxError(s, token{text: s[i : i+1], pos: i}, listSeparator+" or "+closeExp, ErrorList)
+		}
+	}
+
+	return nil, 0, newSyntaxError(s, token{text: openExp, pos: start}, "closing "+closeExp, ErrorList)
+}
+
+// skipSpace returns the offset of the first rune that is not a space from i
+func skipSpace(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		i += size
+	}
+
+	return i
+}
+
+// scanRegex reads the pattern and the flags of the regex opened by the
+// slash at s[start], validates them and returns the offset right after
+func scanRegex(s string, start int) (*RegexNode, int, error) {
+	opening := token{text: regexDelimiter, pos: start}
+	pattern := strings.Builder{}
+
+	i := start + 1
+	for ; i < len(s) && s[i:i+1] != regexDelimiter; i++ {
+		if s[i:i+1] == escape && strings.HasPrefix(s[i+1:], regexDelimiter) {
+			i++
+		}
+
+		pattern.WriteByte(s[i])
+	}
+
+	if i == len(s) {
+		return nil, 0, newSyntaxError(s, opening, "closing "+regexDelimiter, ErrorRegex)
+	}
+
+	end := i + 1
+	for end < len(s) && isASCIILetter(s[end]) {
+		end++
+	}
+
+	r := &RegexNode{Pattern: pattern.String(), Flags: s[i+1 : end]}
+
+	flags := regexsyntax.Perl
+	for _, flag := range r.Flags {
+		if string(flag) != regexIgnoreCase {
+			flagToken := token{text: string(flag), pos: i + 1 + strings.IndexRune(r.Flags, flag)}
+			return nil, 0, newSyntaxError(s, flagToken, "flag "+regexIgnoreCase, ErrorRegex)
+		}
+
+		flags |= regexsyntax.FoldCase
+	}
+
+	if _, err := regexsyntax.Parse(r.Pattern, flags); err != nil {
+		return nil, 0, newSyntaxError(s, opening, "valid regex", fmt.Errorf("%w: %v", ErrorRegex, err))
+	}
+
+	return r, end, nil
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// unquote reads the phrase opened by the quote at s[start] and returns
+// its value and the offset right after the closing quote
+func unquote(s string, start int) (string, int, bool) {
+	quote := s[start : start+1]
+	value := strings.Builder{}
+
+	for i := start + 1; i < len(s); i++ {
+		switch s[i : i+1] {
+		case escape:
+			if i+1 == len(s) {
+				return "", 0, false
+			}
+
+			i++
+			value.WriteByte(s[i])
+		case quote:
+			return value.String(), i + 1, true
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, false
+}
+
+// quote writes s between double quotes escaping what unquote reads back
+func quote(s string) string {
+	r := strings.NewReplacer(escape, escape+escape, quoteDouble, escape+quoteDouble)
+	return quoteDouble + r.Replace(s) + quoteDouble
+}
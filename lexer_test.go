@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func kinds(tokens []token) []tokenKind {
+	r := []tokenKind{}
+	for _, t := range tokens {
+		r = append(r, t.kind)
+	}
+
+	return r
+}
+
+func Test_tokenize_case1(t *testing.T) {
+	tokens, _ := standard.tokenize("(alice or bob) and not carol")
+
+	expected := []tokenKind{
+		tokenOpen, tokenTerm, tokenOr, tokenTerm, tokenClose,
+		tokenAnd, tokenNot, tokenTerm, tokenEOF,
+	}
+	assert.Equal(t, expected, kinds(tokens))
+	assert.Equal(t, "alice", tokens[1].text)
+	assert.Equal(t, 1, tokens[1].pos)
+	assert.Equal(t, 6, tokens[1].end)
+}
+
+func Test_tokenize_case2(t *testing.T) {
+	tokens, _ := standard.tokenize("oregon or android and nothing")
+
+	expected := []tokenKind{
+		tokenTerm, tokenOr, tokenTerm, tokenAnd, tokenTerm, tokenEOF,
+	}
+	assert.Equal(t, expected, kinds(tokens))
+}
+
+func Test_tokenize_case3(t *testing.T) {
+	tokens, _ := standard.tokenize("not(alice)")
+
+	expected := []tokenKind{
+		tokenNot, tokenOpen, tokenTerm, tokenClose, tokenEOF,
+	}
+	assert.Equal(t, expected, kinds(tokens))
+}
+
+func Test_terms_containing_operators(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"oregon or android",
+			[]interface{}{"oregon", "android"},
+			"(col = ? OR col = ?)",
+		},
+		{
+			"nothing and notary",
+			[]interface{}{"nothing", "notary"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"not orchid and (sandy or brand)",
+			[]interface{}{"orchid", "sandy", "brand"},
+			"(NOT (col = ?) AND (col = ? OR col = ?))",
+		},
+		{
+			"alice bob or carol",
+			[]interface{}{"alice bob", "carol"},
+			"(col = ? OR col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		assert.Nil(t, err)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
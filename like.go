@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Like is Column LIKE Pattern ESCAPE '!', or ILIKE when CaseInsensitive.
+// Pattern is expected to be escaped, see LikePattern. The escape is ! as a
+// backslash in a literal is an escape itself for MySQL
+type Like struct {
+	Column          string
+	Pattern         string
+	CaseInsensitive bool
+}
+
+// ToSql renders squirrel.Like or squirrel.ILike followed by the ESCAPE clause
+func (l Like) ToSql() (string, []interface{}, error) {
+	var like squirrel.Sqlizer = squirrel.Like{l.Column: l.Pattern}
+	if l.CaseInsensitive {
+		like = squirrel.ILike{l.Column: l.Pattern}
+	}
+
+	sql, args, err := like.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql + " ESCAPE '" + likeEscape + "'", args, nil
+}
+
+// LikeEscape escapes %, _ and ! in s so LIKE matches them literally
+func LikeEscape(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// LikePattern is LikeEscape turning also * into % and ? into _
+func LikePattern(s string) string {
+	return likeTranslator.Replace(s)
+}
+
+var (
+	likeEscaper = strings.NewReplacer(
+		likeAny, likeEscape+likeAny,
+		likeOne, likeEscape+likeOne,
+		likeEscape, likeEscape+likeEscape,
+	)
+	likeTranslator = strings.NewReplacer(
+		likeAny, likeEscape+likeAny,
+		likeOne, likeEscape+likeOne,
+		likeEscape, likeEscape+likeEscape,
+		wildcardAny, likeAny,
+		wildcardOne, likeOne,
+	)
+)
+
+// LikeTerm returns a Wildcard callback matching the terms on column with
+// LIKE. A quoted term, or one without wildcards, must match as a whole
+func LikeTerm(column string) func(t *TermNode) squirrel.Sqlizer {
+	return func(t *TermNode) squirrel.Sqlizer {
+		return Like{Column: column, Pattern: termPattern(t)}
+	}
+}
+
+// ILikeTerm is LikeTerm ignoring case through ILIKE
+func ILikeTerm(column string) func(t *TermNode) squirrel.Sqlizer {
+	return func(t *TermNode) squirrel.Sqlizer {
+		return Like{Column: column, Pattern: termPattern(t), CaseInsensitive: true}
+	}
+}
+
+func termPattern(t *TermNode) string {
+	if t.Wildcard {
+		return LikePattern(t.Value)
+	}
+
+	return LikeEscape(t.Value)
+}
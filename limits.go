@@ -0,0 +1,63 @@
+package parser
+
+import "fmt"
+
+// Limits bounds the expressions accepted by Parse and Go, a zero field
+// is no limit. Terms counts the leaves, like alice, "a b" or price>10,
+// and Operators counts and, or, not and the other operators, implicit
+// and included. Depth counts the groups, the nots and the right
+// associative operators, like implies, around a leaf, so
+// "not (alice or not bob)" has depth 3 and "a implies b implies c" has
+// depth 2
+type Limits struct {
+	Length    int // bytes of the input
+	Depth     int
+	Terms     int
+	Operators int
+}
+
+// Names of the limits reported by LimitError
+const (
+	limitLength    = "length"
+	limitDepth     = "depth"
+	limitTerms     = "terms"
+	limitOperators = "operators"
+)
+
+// LimitError tells an expression exceeds one of the Limits. It matches
+// ErrLimitExceeded via errors.Is
+type LimitError struct {
+	Limit string // length, depth, terms or operators
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s over %d", ErrLimitExceeded, e.Limit, e.Max)
+}
+
+// Is matches ErrLimitExceeded
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// check returns a LimitError when value exceeds the limit named limit
+func (l Limits) check(limit string, value int) error {
+	var bound int
+
+	switch limit {
+	case limitLength:
+		bound = l.Length
+	case limitDepth:
+		bound = l.Depth
+	case limitTerms:
+		bound = l.Terms
+	case limitOperators:
+		bound = l.Operators
+	}
+
+	if bound > 0 && value > bound {
+		return &LimitError{Limit: limit, Max: bound}
+	}
+
+	return nil
+}
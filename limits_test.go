@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_limits(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithImplicitAnd(), WithLimits(Limits{
+		Length:    200,
+		Depth:     3,
+		Terms:     4,
+		Operators: 3,
+	}))
+
+	accepted := []string{
+		"not (alice or not bob)",
+		"((alice)) and bob or carol",
+		"alice bob carol dave",
+	}
+
+	for _, input := range accepted {
+		_, err := p.Go(input)
+		assert.Nil(t, err, input)
+	}
+
+	cases := []struct {
+		input string
+		limit string
+		max   int
+	}{
+		{strings.Repeat("a", 201), "length", 200},
+		{"((((alice))))", "depth", 3},
+		{"not not not (alice)", "depth", 3},
+		{"not (alice or (not bob))", "depth", 3},
+		{"alice or bob or carol or dave or eve", "operators", 3},
+		{"alice bob carol dave eve", "operators", 3},
+		{"(alice or bob) and (carol or dave or eve)", "operators", 3},
+	}
+
+	for _, curr := range cases {
+		_, err := p.Go(curr.input)
+
+		var limitErr *LimitError
+		if !assert.True(t, errors.As(err, &limitErr), curr.input) {
+			continue
+		}
+
+		assert.True(t, errors.Is(err, ErrLimitExceeded))
+		assert.Equal(t, curr.limit, limitErr.Limit, curr.input)
+		assert.Equal(t, curr.max, limitErr.Max, curr.input)
+	}
+
+	_, err := New(nil, WithLimits(Limits{Terms: 2})).Parse("alice or bob and price")
+	assert.EqualError(t, err, "limit exceeded: terms over 2")
+}
+
+func Test_limits_nesting(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithLimits(Limits{Depth: 100}))
+
+	input := strings.Repeat("(", 100000) + "alice" + strings.Repeat(")", 100000)
+
+	_, err := p.Parse(input)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+
+	_, err = p.Parse(strings.Repeat("(", 100) + "alice" + strings.Repeat(")", 100))
+	assert.Nil(t, err)
+
+	p = New(nil, WithKeywords(ExtendedKeywords()), WithLimits(Limits{Depth: 100}))
+
+	_, err = p.Parse(strings.Repeat("alice implies ", 100000) + "bob")
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+
+	_, err = p.Parse(strings.Repeat("alice implies ", 50) + "bob")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lists(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("body LIKE ?", s)
+	}, WithComparisons("status", "priority"), WithOptimize(CollapseIn))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"status:(open, pending, closed)",
+			[]interface{}{"open", "pending", "closed"},
+			"status IN (?,?,?)",
+		},
+		{
+			`status:( "in progress" ,'on hold',open ) and not priority:(1,2)`,
+			[]interface{}{"in progress", "on hold", "open", "1", "2"},
+			"(status IN (?,?,?) AND NOT (priority IN (?,?)))",
+		},
+		{
+			"status:(open) or (crash)",
+			[]interface{}{"open", "crash"},
+			"(status IN (?) OR body LIKE ?)",
+		},
+		{
+			"status=open or status=pending or status=closed",
+			[]interface{}{"open", "pending", "closed"},
+			"status IN (?,?,?)",
+		},
+		{
+			"status=open or priority=1 or (status=closed or crash) or status:(a, b) or priority>2",
+			[]interface{}{"open", "closed", "a", "b", "1", "crash", "2"},
+			"(((status IN (?,?,?,?) OR priority = ?) OR body LIKE ?) OR priority > ?)",
+		},
+		{
+			"status=open and (status=closed or priority=1)",
+			[]interface{}{"open", "closed", "1"},
+			"(status = ? AND (status = ? OR priority = ?))",
+		},
+		{
+			"not (status=open or status=closed) and priority!=1",
+			[]interface{}{"open", "closed", "1"},
+			"(NOT (status IN (?,?)) AND priority <> ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_lists_tree(t *testing.T) {
+	p := New(nil, WithComparisons("status"))
+
+	n, err := p.Parse(`status:(open, "in progress", 'x,y')`)
+	assert.Nil(t, err)
+	assert.Equal(t, &InNode{Field: "status", Values: []string{"open", "in progress", "x,y"}}, n)
+	assert.Equal(t, `status:(open, "in progress", "x,y")`, n.String())
+}
+
+func Test_lists_collapse_keeps_shape(t *testing.T) {
+	p := New(nil, WithComparisons("status", "priority"))
+
+	n, err := p.Parse("status=open or (priority=1 or status>2)")
+	assert.Nil(t, err)
+	assert.Equal(t, n, CollapseIn(n))
+
+	n, err = p.Parse("status=open or (priority=1 or status=2)")
+	assert.Nil(t, err)
+
+	expected := &OrNode{
+		Left:  &InNode{Field: "status", Values: []string{"open", "2"}},
+		Right: &ComparisonNode{Field: "priority", Operator: "=", Value: "1"},
+	}
+	assert.Equal(t, expected, CollapseIn(n))
+	assert.Equal(t, "status=open or (priority=1 or status=2)", n.String())
+}
+
+func Test_lists_without_optimize(t *testing.T) {
+	compared := []string{}
+	p := New(nil, WithComparisons("status"), WithCompare(func(c *ComparisonNode) squirrel.Sqlizer {
+		compared = append(compared, c.Value)
+		return DefaultCompare(c)
+	}))
+
+	exp, err := p.Go("status=open or status=closed")
+	assert.Nil(t, err)
+
+	sql, _, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "(status = ? OR status = ?)", sql)
+	assert.Equal(t, []string{"open", "closed"}, compared)
+}
+
+func Test_lists_on_fields(t *testing.T) {
+	p := New(nil, WithField("title", func(value string) squirrel.Sqlizer {
+		return squirrel.Like{"title_text": "%" + value + "%"}
+	}), WithField("status", func(value string) squirrel.Sqlizer {
+		return squirrel.Eq{"state": value}
+	}), WithComparisons("status"), WithOptimize(CollapseIn))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"title:(go, rust)",
+			[]interface{}{"%go%", "%rust%"},
+			"(title_text LIKE ? OR title_text LIKE ?)",
+		},
+		{
+			"title:go or title:rust or title:(c)",
+			[]interface{}{"%go%", "%rust%", "%c%"},
+			"((title_text LIKE ? OR title_text LIKE ?) OR title_text LIKE ?)",
+		},
+		{
+			"title:go and title:rust",
+			[]interface{}{"%go%", "%rust%"},
+			"(title_text LIKE ? AND title_text LIKE ?)",
+		},
+		{
+			"status:(open, closed) or status=new",
+			[]interface{}{"open", "closed", "new"},
+			"status IN (?,?,?)",
+		},
+		{
+			"status:open or status:closed",
+			[]interface{}{"open", "closed"},
+			"(state = ? OR state = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_lists_errors(t *testing.T) {
+	p := New(nil, WithComparisons("status"))
+
+	cases := []struct {
+		input    string
+		offset   int
+		expected string
+		err      error
+	}{
+		{"status:(open, closed", 7, "closing )", ErrorList},
+		{"status:()", 8, "value", ErrorList},
+		{"status:(open,,closed)", 13, "value", ErrorList},
+		{`status:("open" x)`, 15, ", or )", ErrorList},
+		{`status:("open)`, 8, `closing "`, ErrorQuotes},
+	}
+
+	for _, curr := range cases {
+		_, err := p.Parse(curr.input)
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), curr.input) {
+			continue
+		}
+
+		assert.Equal(t, curr.offset, syntaxErr.Offset, curr.input)
+		assert.Equal(t, curr.expected, syntaxErr.Expected, curr.input)
+		assert.True(t, errors.Is(err, curr.err), curr.input)
+	}
+
+	_, err := (&parser2{}).Compile(&InNode{Field: "status"})
+	assert.Equal(t, ErrorNotDefinedIn, err)
+}
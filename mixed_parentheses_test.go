@@ -7,36 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_not_followed_by_parentheses_case1(t *testing.T) {
-	parts, err := splitParentheses("alice and not (bob)")
-	assert.Nil(t, err)
-	assert.Equal(t, []string{"alice and ", "not (bob)"}, parts)
-}
-
-func Test_not_followed_by_parentheses_case2(t *testing.T) {
-	parts, err := splitAnd("alice and not (bob)")
-	assert.Nil(t, err)
-	assert.Equal(t, []string{"alice", "not (bob)"}, parts)
-}
-
-func Test_not_followed_by_parentheses_case3(t *testing.T) {
-	parts, err := splitParentheses("alice or not (bob)")
-	assert.Nil(t, err)
-	assert.Equal(t, []string{"alice or ", "not (bob)"}, parts)
-}
-
-func Test_not_followed_by_parentheses_case4(t *testing.T) {
-	parts, err := splitOr("alice or not (bob)")
-	assert.Nil(t, err)
-	assert.Equal(t, []string{"alice", "not (bob)"}, parts)
-}
-
-func Test_not_followed_by_parentheses_case5(t *testing.T) {
-	parts, err := splitParentheses("alice and not (bob or not carol)")
-	assert.Nil(t, err)
-	assert.Equal(t, []string{"alice and ", "not (bob or not carol)"}, parts)
-}
-
 func Test_not_followed_by_parentheses_case6(t *testing.T) {
 	assert.True(t, testExpression("alice and not (bob or not carol)"))
 }
@@ -0,0 +1,24 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Not is NOT (Operand). Operand is rendered by ToSql, not before, so its
+// arguments keep their order and the placeholders are formatted once by
+// the statement holding Not
+type Not struct {
+	Operand squirrel.Sqlizer
+}
+
+// ToSql renders NOT (Operand). An error of Operand is wrapped
+func (n Not) ToSql() (string, []interface{}, error) {
+	sql, args, err := n.Operand.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("NOT operand: %w", err)
+	}
+
+	return fmt.Sprintf("NOT (%s)", sql), args, nil
+}
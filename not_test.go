@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+// counted counts its renderings
+type counted struct {
+	value string
+	calls *int
+}
+
+func (c counted) ToSql() (string, []interface{}, error) {
+	*c.calls++
+	return "col = ?", []interface{}{c.value}, nil
+}
+
+func Test_not_lazy(t *testing.T) {
+	calls := 0
+	p := New(func(s string) squirrel.Sqlizer {
+		return counted{value: s, calls: &calls}
+	})
+
+	exp, err := p.Go("not alice and not (bob or not carol)")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, calls)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []interface{}{"alice", "bob", "carol"}, v)
+	assert.Equal(t, "(NOT (col = ?) AND NOT ((col = ? OR NOT (col = ?))))", sql)
+}
+
+func Test_not_placeholders(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Eq{"col": s}
+	}, WithComparisons("price"))
+
+	exp, err := p.Go("alice and not (price>10 or bob) and not carol")
+	assert.Nil(t, err)
+
+	sql, v, err := squirrel.Select("*").From("t").Where(exp).PlaceholderFormat(squirrel.Dollar).ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice", "10", "bob", "carol"}, v)
+	assert.Equal(t, "SELECT * FROM t WHERE ((col = $1 AND NOT ((price > $2 OR col = $3))) AND NOT (col = $4))", sql)
+}
+
+func Test_not_mutated(t *testing.T) {
+	inner := squirrel.Eq{"col": "alice"}
+	not := Not{Operand: inner}
+
+	inner["col"] = "bob"
+
+	sql, v, err := not.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"bob"}, v)
+	assert.Equal(t, "NOT (col = ?)", sql)
+}
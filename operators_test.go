@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operators_ignore_case(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"alice AND bob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"alice Or NOT bob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? OR NOT (col = ?))",
+		},
+		{
+			"Not (alice oR bob) anD carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"(NOT ((col = ? OR col = ?)) AND col = ?)",
+		},
+		{
+			"ANDROID OR OREGON",
+			[]interface{}{"ANDROID", "OREGON"},
+			"(col = ? OR col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		assert.Nil(t, err, curr.input)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_operators_symbols(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}, WithSymbols())
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"alice && bob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"alice&&bob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"AT&T & (a|b | c)",
+			[]interface{}{"AT&T", "a|b", "c"},
+			"(col = ? AND (col = ? OR col = ?))",
+		},
+		{
+			"alice || bob | carol",
+			[]interface{}{"alice", "bob", "carol"},
+			"((col = ? OR col = ?) OR col = ?)",
+		},
+		{
+			"!alice && -bob",
+			[]interface{}{"alice", "bob"},
+			"(NOT (col = ?) AND NOT (col = ?))",
+		},
+		{
+			"!(alice | bob) and -(carol)",
+			[]interface{}{"alice", "bob", "carol"},
+			"(NOT ((col = ? OR col = ?)) AND NOT (col = ?))",
+		},
+		{
+			`e-mail or hello! or -"x - y"`,
+			[]interface{}{"e-mail", "hello!", "x - y"},
+			"((col = ? OR col = ?) OR NOT (col = ?))",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		assert.Nil(t, err, curr.input)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_operators_symbols_disabled(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	exp, err := p.Go("alice&&bob or -carol")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"alice&&bob", "-carol"}, v)
+	assert.Equal(t, "(col = ? OR col = ?)", sql)
+}
+
+func Test_operators_symbols_errors(t *testing.T) {
+	p := New(nil, WithSymbols())
+
+	for _, input := range []string{"alice &&", "&& alice", "alice | | bob", "alice !"} {
+		_, err := p.Parse(input)
+		assert.NotNil(t, err, input)
+	}
+}
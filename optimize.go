@@ -0,0 +1,168 @@
+package parser
+
+// CollapseIn rewrites the chains of or whose operands test the same field
+// for equality, like status=open or status=closed or status:(a, b), into a
+// single InNode placed where the field first appears. The InNode goes to In,
+// so the collapsed comparisons no longer reach Compare. Field terms like
+// status:open keep their handler and are not collapsed. The other operands
+// keep their order and a chain without such operands keeps its shape.
+// A node without such chains below is returned as is
+func CollapseIn(n Node) Node {
+	switch n := n.(type) {
+	case *OrNode:
+		return collapseOr(n)
+	case *AndNode:
+		if left, right, changed := collapseBoth(n.Left, n.Right); changed {
+			return &AndNode{Left: left, Right: right}
+		}
+	case *NotNode:
+		if operand := CollapseIn(n.Operand); operand != n.Operand {
+			return &NotNode{Operand: operand}
+		}
+	case *XorNode:
+		if left, right, changed := collapseBoth(n.Left, n.Right); changed {
+			return &XorNode{Left: left, Right: right}
+		}
+	case *NandNode:
+		if left, right, changed := collapseBoth(n.Left, n.Right); changed {
+			return &NandNode{Left: left, Right: right}
+		}
+	case *NorNode:
+		if left, right, changed := collapseBoth(n.Left, n.Right); changed {
+			return &NorNode{Left: left, Right: right}
+		}
+	case *ImpliesNode:
+		if left, right, changed := collapseBoth(n.Left, n.Right); changed {
+			return &ImpliesNode{Left: left, Right: right}
+		}
+	case *GroupNode:
+		if inner := CollapseIn(n.Inner); inner != n.Inner {
+			return &GroupNode{Inner: inner}
+		}
+	}
+
+	return n
+}
+
+func collapseBoth(left, right Node) (Node, Node, bool) {
+	l, r := CollapseIn(left), CollapseIn(right)
+	return l, r, l != left || r != right
+}
+
+// equalities gathers the operands testing the same field for equality
+type equalities struct {
+	index   int
+	count   int
+	operand Node
+	list    *InNode
+}
+
+func collapseOr(n *OrNode) Node {
+	operands := orOperands(n, []Node{})
+	processed := map[Node]Node{}
+
+	collapsed := []Node{}
+	fields := map[string]*equalities{}
+	merged := false
+
+	for _, operand := range operands {
+		p := CollapseIn(operand)
+		processed[operand] = p
+
+		field, values, ok := equality(p)
+		if !ok {
+			collapsed = append(collapsed, p)
+			continue
+		}
+
+		if e, ok := fields[field]; ok {
+			e.list.Values = append(e.list.Values, values...)
+			e.count++
+			merged = true
+			continue
+		}
+
+		list := &InNode{Field: field, Values: append([]string{}, values...)}
+		fields[field] = &equalities{index: len(collapsed), count: 1, operand: p, list: list}
+		collapsed = append(collapsed, list)
+	}
+
+	if !merged {
+		return replaceOperands(n, processed)
+	}
+
+	for _, e := range fields {
+		if e.count == 1 {
+			collapsed[e.index] = e.operand
+		}
+	}
+
+	r := collapsed[0]
+	for _, operand := range collapsed[1:] {
+		r = &OrNode{Left: r, Right: operand}
+	}
+
+	return r
+}
+
+// orOperands appends the operands of the chain of or n
+func orOperands(n Node, operands []Node) []Node {
+	switch n := n.(type) {
+	case *OrNode:
+		return orOperands(n.Right, orOperands(n.Left, operands))
+	case *GroupNode:
+		if _, ok := unwrap(n).(*OrNode); ok {
+			return orOperands(n.Inner, operands)
+		}
+	}
+
+	return append(operands, n)
+}
+
+// andOperands appends the operands of the chain of and n
+func andOperands(n Node, operands []Node) []Node {
+	switch n := n.(type) {
+	case *AndNode:
+		return andOperands(n.Right, andOperands(n.Left, operands))
+	case *GroupNode:
+		if _, ok := unwrap(n).(*AndNode); ok {
+			return andOperands(n.Inner, operands)
+		}
+	}
+
+	return append(operands, n)
+}
+
+// replaceOperands rebuilds the chain of or n with the processed operands
+func replaceOperands(n Node, processed map[Node]Node) Node {
+	if p, ok := processed[n]; ok {
+		return p
+	}
+
+	switch n := n.(type) {
+	case *OrNode:
+		left, right := replaceOperands(n.Left, processed), replaceOperands(n.Right, processed)
+		if left != n.Left || right != n.Right {
+			return &OrNode{Left: left, Right: right}
+		}
+	case *GroupNode:
+		if inner := replaceOperands(n.Inner, processed); inner != n.Inner {
+			return &GroupNode{Inner: inner}
+		}
+	}
+
+	return n
+}
+
+// equality tells the field and values tested by n when n is field=value
+// or field:(values)
+func equality(n Node) (string, []string, bool) {
+	switch n := unwrap(n).(type) {
+	case *ComparisonNode:
+		return n.Field, []string{n.Value}, n.Operator == compareEq
+	case *InNode:
+		return n.Field, n.Values, true
+	}
+
+	return "", nil, false
+}
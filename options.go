@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Option configures the parser built by New
+type Option func(*parser2)
+
+// WithSymbols enables &&, &, ||, | and ! as aliases of and, or and not,
+// and -term as an alias of not term. A single & or | inside a word, like
+// AT&T, is part of the term
+func WithSymbols() Option {
+	return func(p *parser2) {
+		p.syntax.symbols = true
+	}
+}
+
+// WithKeywords replaces and, or and not by the spellings in k. When k
+// does not pass Validate, Go, Parse and Compile return its error
+func WithKeywords(k Keywords) Option {
+	return func(p *parser2) {
+		table, err := k.syntax()
+		if err != nil {
+			p.fail(err)
+			return
+		}
+
+		sx := *p.syntax
+		sx.keywords = table.keywords
+		sx.names = table.names
+		p.syntax = &sx
+	}
+}
+
+// WithPrecedence replaces StandardPrecedence, LeftToRightPrecedence
+// evaluates every operator left to right. When precedence does not pass
+// Validate, Go, Parse and Compile return its error
+func WithPrecedence(precedence Precedence) Option {
+	return func(p *parser2) {
+		levels, err := precedence.levels()
+		if err != nil {
+			p.fail(err)
+			return
+		}
+
+		p.syntax.levels = levels
+	}
+}
+
+// WithImplicitAnd joins adjacent terms with and, so "alice bob" is
+// alice and bob instead of the term "alice bob". A phrase stays one term
+func WithImplicitAnd() Option {
+	return func(p *parser2) {
+		p.syntax.implicitAnd = true
+	}
+}
+
+// WithField registers the handler of the terms written as name:value. A
+// list written as name:(a, b) calls the handler for each value and joins
+// them by or, unless name is enabled by WithComparisons too, then it goes
+// to In. Once a field is registered, a term naming any other field is an
+// error
+func WithField(name string, handler FieldHandler) Option {
+	return func(p *parser2) {
+		if p.Fields == nil {
+			p.Fields = map[string]FieldHandler{}
+		}
+
+		if p.syntax.fields == nil {
+			p.syntax.fields = map[string]bool{}
+		}
+
+		p.Fields[name] = handler
+		p.syntax.fields[name] = true
+	}
+}
+
+// WithFieldNames enables the terms written as name:value on the given
+// fields without registering handlers, for the builders of NewOf,
+// NewEvaluator and NewElastic which compile the field terms themselves.
+// New reports the field terms without handler as an UnknownFieldError
+func WithFieldNames(names ...string) Option {
+	return func(p *parser2) {
+		if p.syntax.fields == nil {
+			p.syntax.fields = map[string]bool{}
+		}
+
+		for _, name := range names {
+			p.syntax.fields[name] = true
+		}
+	}
+}
+
+// WithFieldE is WithField with a handler that can reject the value, the
+// error is returned by Go as a CompileError
+func WithFieldE(name string, handler FieldHandlerE) Option {
+	return func(p *parser2) {
+		if p.FieldsE == nil {
+			p.FieldsE = map[string]FieldHandlerE{}
+		}
+
+		if p.syntax.fields == nil {
+			p.syntax.fields = map[string]bool{}
+		}
+
+		p.FieldsE[name] = handler
+		p.syntax.fields[name] = true
+	}
+}
+
+// WithFieldContext is WithFieldE with a handler receiving the context
+// given to GoContext, or context.Background for Go
+func WithFieldContext(name string, handler FieldHandlerContext) Option {
+	return func(p *parser2) {
+		if p.FieldsContext == nil {
+			p.FieldsContext = map[string]FieldHandlerContext{}
+		}
+
+		if p.syntax.fields == nil {
+			p.syntax.fields = map[string]bool{}
+		}
+
+		p.FieldsContext[name] = handler
+		p.syntax.fields[name] = true
+	}
+}
+
+// WithComparisons enables terms like price>=10, which Compare receives,
+// ranges like price:[10 TO 20], which Range receives, and lists like
+// price:(10, 20), which In receives, on the given fields. Once enabled,
+// a comparison, a range or a list on any other field is an error
+func WithComparisons(fields ...string) Option {
+	return func(p *parser2) {
+		if p.syntax.comparisons == nil {
+			p.syntax.comparisons = map[string]bool{}
+		}
+
+		for _, field := range fields {
+			p.syntax.comparisons[field] = true
+		}
+	}
+}
+
+// WithCompare replaces DefaultCompare
+func WithCompare(compare func(c *ComparisonNode) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.Compare = compare
+	}
+}
+
+// WithRange replaces DefaultRange
+func WithRange(r func(r *RangeNode) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.Range = r
+	}
+}
+
+// WithIn replaces DefaultIn
+func WithIn(in func(n *InNode) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.In = in
+	}
+}
+
+// WithOptimize sets the rewrite applied before compiling, like CollapseIn.
+// By default the tree is compiled as parsed
+func WithOptimize(optimize func(n Node) Node) Option {
+	return func(p *parser2) {
+		p.Optimize = optimize
+	}
+}
+
+// WithWildcard compiles the terms containing * or ? through wildcard
+// instead of Str, see LikeTerm and ILikeTerm
+func WithWildcard(wildcard func(t *TermNode) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.Wildcard = wildcard
+	}
+}
+
+// WithRegex enables terms like /err(or)?/i, matched on column, and like
+// message:/^panic/, matched on a field enabled by WithComparisons. The
+// dialect renders them, or fails when it does not support regexes. With
+// an empty column the regexes without field fail to compile
+func WithRegex(dialect Dialect, column string) Option {
+	return func(p *parser2) {
+		p.Dialect = dialect
+		p.RegexColumn = column
+		p.syntax.regex = true
+	}
+}
+
+// WithRegexNames enables the terms like /err(or)?/i and message:/^panic/
+// without a dialect, for the builders of NewOf and NewEvaluator which
+// compile the regexes themselves. New reports them as not defined Dialect
+func WithRegexNames() Option {
+	return func(p *parser2) {
+		p.syntax.regex = true
+	}
+}
+
+// WithXor compiles xor through xor instead of its expansion in and, or
+// and not
+func WithXor(xor func(a, b squirrel.Sqlizer) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.XOR = xor
+	}
+}
+
+// WithNand compiles nand through nand instead of not (a and b)
+func WithNand(nand func(a, b squirrel.Sqlizer) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.NAND = nand
+	}
+}
+
+// WithNor compiles nor through nor instead of not (a or b)
+func WithNor(nor func(a, b squirrel.Sqlizer) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.NOR = nor
+	}
+}
+
+// WithImplies compiles implies through implies instead of not a or b
+func WithImplies(implies func(a, b squirrel.Sqlizer) squirrel.Sqlizer) Option {
+	return func(p *parser2) {
+		p.IMPLIES = implies
+	}
+}
+
+// WithFlatten compiles a chain of or, like a or b or c, into a single
+// squirrel.Or{a, b, c} instead of nested ones, and a chain of and into a
+// single squirrel.And
+func WithFlatten() Option {
+	return func(p *parser2) {
+		p.ORAll = func(operands []squirrel.Sqlizer) squirrel.Or {
+			return squirrel.Or(operands)
+		}
+
+		p.ANDAll = func(operands []squirrel.Sqlizer) squirrel.And {
+			return squirrel.And(operands)
+		}
+	}
+}
+
+// WithORAll compiles a chain of or through orAll, receiving its operands
+// in order, instead of ExpORExp and the other binary callbacks
+func WithORAll(orAll func(operands []squirrel.Sqlizer) squirrel.Or) Option {
+	return func(p *parser2) {
+		p.ORAll = orAll
+	}
+}
+
+// WithANDAll compiles a chain of and through andAll, receiving its
+// operands in order, instead of ExpANDExp and the other binary callbacks
+func WithANDAll(andAll func(operands []squirrel.Sqlizer) squirrel.And) Option {
+	return func(p *parser2) {
+		p.ANDAll = andAll
+	}
+}
+
+// WithBuilder replaces the StrBuilder made from the Str given to New
+func WithBuilder(b Builder[squirrel.Sqlizer]) Option {
+	return func(p *parser2) {
+		p.Builder = b
+	}
+}
+
+// WithStrE compiles the terms through str instead of the Str given to New,
+// so a term can be rejected. The error is returned by Go as a CompileError
+func WithStrE(str func(search string) (squirrel.Sqlizer, error)) Option {
+	return func(p *parser2) {
+		p.StrE = str
+	}
+}
+
+// WithStrContext is WithStrE with a function receiving the context given
+// to GoContext, or context.Background for Go
+func WithStrContext(str func(ctx context.Context, search string) (squirrel.Sqlizer, error)) Option {
+	return func(p *parser2) {
+		p.StrContext = str
+	}
+}
+
+// WithLimits rejects the expressions exceeding l with a LimitError
+func WithLimits(l Limits) Option {
+	return func(p *parser2) {
+		p.syntax.limits = l
+	}
+}
@@ -7,179 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-/*
-  Cases tested:
-	splitParentheses("(alice or bob) and carol")
-	splitParentheses("alice or (bob and carol)")
-	splitParentheses("(alice or bob) and (carol or dan)")
-	splitParentheses("(alice or bob) and (carol or dan) or (elen and (frank or glenn))")
-	splitParentheses("(alice or bob) and carol or dan or (elen and (frank or glenn))")
-	splitParentheses("(alice or bob) and not (carol or dan) or (elen and not (frank or glenn))")
-*/
-func Test_splitParentheses_case1(t *testing.T) {
-	terms, err := splitParentheses("(alice or bob) and carol")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		" and carol",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitParentheses_case2(t *testing.T) {
-	terms, err := splitParentheses("alice or (bob and carol)")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"alice or ",
-		"(bob and carol)",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitParentheses_case3(t *testing.T) {
-	terms, err := splitParentheses("(alice or bob) and (carol or dan)")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		" and ",
-		"(carol or dan)",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitParentheses_case4(t *testing.T) {
-	terms, err := splitParentheses("(alice or bob) and (carol or dan) or (elen and (frank or glenn))")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		" and ",
-		"(carol or dan)",
-		" or ",
-		"(elen and (frank or glenn))",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitParentheses_case5(t *testing.T) {
-	terms, err := splitParentheses("(alice or bob) and carol or dan or (elen and (frank or glenn))")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		" and carol or dan or ",
-		"(elen and (frank or glenn))",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitParentheses_case6(t *testing.T) {
-	terms, err := splitParentheses("(alice or bob) and not (carol or dan) or (elen and not (frank or glenn))")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		" and ",
-		"not (carol or dan)",
-		" or ",
-		"(elen and not (frank or glenn))",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitOr_case1(t *testing.T) {
-	terms, err := splitOr("(alice and bob) or carol")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice and bob)",
-		"carol",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitOr_case2(t *testing.T) {
-	terms, err := splitOr("zero or (alice and bob) or carol or (dan and elen) or (frank and glenn)")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"zero",
-		"(alice and bob)",
-		"carol",
-		"(dan and elen)",
-		"(frank and glenn)",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitAnd_case3(t *testing.T) {
-	terms, err := splitAnd("(alice or bob) and carol")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"(alice or bob)",
-		"carol",
-	}
-	assert.Equal(t, expected, terms)
-}
-
-func Test_splitAnd_case4(t *testing.T) {
-	terms, err := splitAnd("zero and (alice or bob) and carol and (dan or elen) and (frank or glenn)")
-
-	if err != nil {
-		t.Error(err)
-		return
-	}
-
-	expected := []string{
-		"zero",
-		"(alice or bob)",
-		"carol",
-		"(dan or elen)",
-		"(frank or glenn)",
-	}
-	assert.Equal(t, expected, terms)
-}
-
 /*
 Cases tested:
 	p.Go("(alice or bob) and carol")                                	  // (a | b) & c
@@ -575,15 +402,3 @@ func Test_parenthesis_parser_case8(t *testing.T) {
 	assert.Equal(t, 2, ExpORExpCalled)
 	assert.Equal(t, 2, NotExpCalled)
 }
-
-func Test_parenthesis_parser_case9(t *testing.T) {
-	expected := []string{"not (alice and bob)", "(carol and dan)", "(elen and gleen)"}
-	actual, err := splitAnd("not (alice and bob) and (carol and dan) and (elen and gleen)")
-
-	assert.Nil(t, err)
-	assert.Equal(t, expected, actual)
-}
-
-func Test_parenthesis_parser_case10(t *testing.T) {
-	assert.True(t, isTerm("not (alice and bob)"))
-}
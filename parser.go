@@ -1,8 +1,9 @@
 package parser
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"sort"
 
 	"github.com/Masterminds/squirrel"
 )
@@ -14,34 +15,110 @@ var (
 	ErrorOperators = fmt.Errorf("operator do not match")
 	// ErrorExpression defines it
 	ErrorExpression = fmt.Errorf("incorrect expression")
+	// ErrorQuotes defines it
+	ErrorQuotes = fmt.Errorf("quotes do not match")
+	// ErrorRange defines it
+	ErrorRange = fmt.Errorf("incorrect range")
+	// ErrorList defines it
+	ErrorList = fmt.Errorf("incorrect list")
+	// ErrorRegex defines it
+	ErrorRegex = fmt.Errorf("incorrect regex")
+	// ErrorRegexUnsupported defines it
+	ErrorRegexUnsupported = fmt.Errorf("regex not supported by the dialect")
+	// ErrorKeywordConflict defines it
+	ErrorKeywordConflict = fmt.Errorf("keyword used by two operators")
+	// ErrorKeywordInvalid defines it
+	ErrorKeywordInvalid = fmt.Errorf("invalid keyword")
+	// ErrorPrecedenceInvalid defines it
+	ErrorPrecedenceInvalid = fmt.Errorf("invalid precedence")
+	// ErrLimitExceeded defines it, see LimitError
+	ErrLimitExceeded = fmt.Errorf("limit exceeded")
 )
 
 // Error definitions
 var (
-	ErrorNotDefinedStrORStr  = fmt.Errorf("not defined StrORStr")
-	ErrorNotDefinedExpORStr  = fmt.Errorf("not defined ExpORStr")
-	ErrorNotDefinedStrORExp  = fmt.Errorf("not defined StrORExp")
-	ErrorNotDefinedExpORExp  = fmt.Errorf("not defined ExpORExp")
-	ErrorNotDefinedStrANDStr = fmt.Errorf("not defined StrANDStr")
-	ErrorNotDefinedExpANDStr = fmt.Errorf("not defined ExpANDStr")
-	ErrorNotDefinedStrANDExp = fmt.Errorf("not defined StrANDExp")
-	ErrorNotDefinedExpANDExp = fmt.Errorf("not defined ExpANDExp")
-	ErrorNotDefinedNotStr    = fmt.Errorf("not defined NotStr")
-	ErrorNotDefinedNotExp    = fmt.Errorf("not defined NotExp")
-	ErrorNotDefinedStr       = fmt.Errorf("not defined Str")
+	ErrorNotDefinedStrORStr     = fmt.Errorf("not defined StrORStr")
+	ErrorNotDefinedExpORStr     = fmt.Errorf("not defined ExpORStr")
+	ErrorNotDefinedStrORExp     = fmt.Errorf("not defined StrORExp")
+	ErrorNotDefinedExpORExp     = fmt.Errorf("not defined ExpORExp")
+	ErrorNotDefinedStrANDStr    = fmt.Errorf("not defined StrANDStr")
+	ErrorNotDefinedExpANDStr    = fmt.Errorf("not defined ExpANDStr")
+	ErrorNotDefinedStrANDExp    = fmt.Errorf("not defined StrANDExp")
+	ErrorNotDefinedExpANDExp    = fmt.Errorf("not defined ExpANDExp")
+	ErrorNotDefinedNotStr       = fmt.Errorf("not defined NotStr")
+	ErrorNotDefinedNotExp       = fmt.Errorf("not defined NotExp")
+	ErrorNotDefinedStr          = fmt.Errorf("not defined Str")
+	ErrorNotDefinedCompare      = fmt.Errorf("not defined Compare")
+	ErrorNotDefinedRange        = fmt.Errorf("not defined Range")
+	ErrorNotDefinedIn           = fmt.Errorf("not defined In")
+	ErrorNotDefinedDialect      = fmt.Errorf("not defined Dialect")
+	ErrorNotDefinedLeaf         = fmt.Errorf("not defined Leaf")
+	ErrorNotDefinedDefaultField = fmt.Errorf("not defined DefaultField")
+	ErrorNotDefinedRegexColumn  = fmt.Errorf("not defined RegexColumn")
+	ErrorOptionUnsupported      = fmt.Errorf("option not supported by NewOf")
 )
 
+// comparisons are tried in order so the longest operator wins
+var comparisons = []string{
+	compareNotEq,
+	compareGtEq,
+	compareLtEq,
+	compareEq,
+	compareGt,
+	compareLt,
+}
+
 const (
-	operatorAnd = " and "
-	operatorOr  = " or "
-	operatorNot = "not "
-	openExp     = "("
-	closeExp    = ")"
-	separator   = " "
+	operatorAnd     = "and"
+	operatorOr      = "or"
+	operatorNot     = "not"
+	operatorXor     = "xor"
+	operatorNand    = "nand"
+	operatorNor     = "nor"
+	operatorImplies = "implies"
+	openExp         = "("
+	closeExp        = ")"
+	separator       = " "
+	quoteDouble     = `"`
+	quoteSingle     = "'"
+	escape          = `\`
+	fieldSeparator  = ":"
+
+	rangeInclusiveOpen  = "["
+	rangeInclusiveClose = "]"
+	rangeExclusiveOpen  = "{"
+	rangeExclusiveClose = "}"
+	rangeTo             = "TO"
+	rangeOpenBound      = "*"
+	listSeparator       = ","
+	wildcardAny         = "*"
+	wildcardOne         = "?"
+	likeAny             = "%"
+	likeOne             = "_"
+	likeEscape          = "!"
+	regexDelimiter      = "/"
+	regexIgnoreCase     = "i"
+	luceneOperators     = `@&~<>#"`
+	regexAnchors        = "bBAz"
+
+	compareEq    = "="
+	compareNotEq = "!="
+	compareGt    = ">"
+	compareGtEq  = ">="
+	compareLt    = "<"
+	compareLtEq  = "<="
+	symbolAnd    = "&"
+	symbolOr     = "|"
+	symbolNot    = "!"
+	symbolMinus  = "-"
 )
 
-// parser2 is the parser
+// parser2 is the parser. It compiles through Builder when set, otherwise
+// through the callbacks StrORStr to NotExp, which tell a term from an
+// expression
 type parser2 struct {
+	Builder Builder[squirrel.Sqlizer]
+
 	StrORStr func(a, b string) squirrel.Or
 	ExpORStr func(a squirrel.Sqlizer, b string) squirrel.Or
 	StrORExp func(a string, b squirrel.Sqlizer) squirrel.Or
@@ -55,10 +132,99 @@ type parser2 struct {
 	NotStr func(a string) squirrel.Sqlizer
 	NotExp func(a squirrel.Sqlizer) squirrel.Sqlizer
 
-	Str func(a string) squirrel.Sqlizer
+	XOR     func(a, b squirrel.Sqlizer) squirrel.Sqlizer
+	NAND    func(a, b squirrel.Sqlizer) squirrel.Sqlizer
+	NOR     func(a, b squirrel.Sqlizer) squirrel.Sqlizer
+	IMPLIES func(a, b squirrel.Sqlizer) squirrel.Sqlizer
+
+	Str        func(a string) squirrel.Sqlizer
+	StrE       func(a string) (squirrel.Sqlizer, error)
+	StrContext func(ctx context.Context, a string) (squirrel.Sqlizer, error)
+
+	ORAll  func(operands []squirrel.Sqlizer) squirrel.Or
+	ANDAll func(operands []squirrel.Sqlizer) squirrel.And
+
+	Fields        map[string]FieldHandler
+	FieldsE       map[string]FieldHandlerE
+	FieldsContext map[string]FieldHandlerContext
+
+	Compare func(c *ComparisonNode) squirrel.Sqlizer
+	Range   func(r *RangeNode) squirrel.Sqlizer
+	In      func(n *InNode) squirrel.Sqlizer
+
+	Wildcard func(t *TermNode) squirrel.Sqlizer
+
+	Dialect     Dialect
+	RegexColumn string
+
+	Optimize func(n Node) Node
+
+	syntax *syntax
+	err    error
 }
 
-func (p *parser2) processOr(s string) (squirrel.Sqlizer, bool, error) {
+// fail keeps the first error of the options, returned by Go, Parse and
+// Compile
+func (p *parser2) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// squirrelOption names the first option setting a squirrel callback, which
+// the parsers built by NewOf can not use
+func (p *parser2) squirrelOption() string {
+	switch {
+	case p.Builder != nil:
+		return "WithBuilder"
+	case p.Fields != nil:
+		return "WithField"
+	case p.FieldsE != nil:
+		return "WithFieldE"
+	case p.FieldsContext != nil:
+		return "WithFieldContext"
+	case p.Compare != nil:
+		return "WithCompare"
+	case p.Range != nil:
+		return "WithRange"
+	case p.In != nil:
+		return "WithIn"
+	case p.Wildcard != nil:
+		return "WithWildcard"
+	case p.Dialect != nil || p.RegexColumn != "":
+		return "WithRegex"
+	case p.XOR != nil:
+		return "WithXor"
+	case p.NAND != nil:
+		return "WithNand"
+	case p.NOR != nil:
+		return "WithNor"
+	case p.IMPLIES != nil:
+		return "WithImplies"
+	case p.ORAll != nil:
+		return "WithORAll or WithFlatten"
+	case p.ANDAll != nil:
+		return "WithANDAll or WithFlatten"
+	case p.StrE != nil:
+		return "WithStrE"
+	case p.StrContext != nil:
+		return "WithStrContext"
+	}
+
+	return ""
+}
+
+// FieldHandler turns the value of a field:value term into squirrel
+type FieldHandler func(value string) squirrel.Sqlizer
+
+// FieldHandlerE is a FieldHandler that can reject the value
+type FieldHandlerE func(value string) (squirrel.Sqlizer, error)
+
+// FieldHandlerContext is a FieldHandlerE receiving the context given to
+// GoContext
+type FieldHandlerContext func(ctx context.Context, value string) (squirrel.Sqlizer, error)
+
+func (p *parser2) compileOr(ctx context.Context, left, right Node) (squirrel.Sqlizer, error) {
 	/*
 		Using:
 			ExpORExp
@@ -67,304 +233,503 @@ func (p *parser2) processOr(s string) (squirrel.Sqlizer, bool, error) {
 			StrORStr
 	*/
 
-	st, err := simplify(s)
-	if err != nil {
-		return nil, true, err
-	}
+	if p.Builder != nil {
+		leftExp, rightExp, err := p.compileBoth(ctx, left, right)
+		if err != nil {
+			return nil, err
+		}
 
-	terms, err := splitOr(st)
-	if err != nil {
-		return nil, true, err
+		return p.Builder.Or(leftExp, rightExp), nil
 	}
 
-	if len(terms) == 2 {
-		firstTerm, err := simplify(terms[0])
-		if err == ErrorParentheses {
-			return nil, false, err
+	firstTerm, firstIsTerm := p.asTerm(left)
+	lastTerm, lastIsTerm := p.asTerm(right)
+
+	if !firstIsTerm && !lastIsTerm {
+		leftExp, rightExp, err := p.compileBoth(ctx, left, right)
+		if err != nil {
+			return nil, err
 		}
 
-		lastTerm, err := simplify(terms[1])
-		if err == ErrorParentheses {
-			return nil, false, err
+		if p.ExpORExp == nil {
+			return nil, ErrorNotDefinedExpORExp
 		}
 
-		firstTermContainsOperator := containsOperator(firstTerm)
-		lastTermContainsOperator := containsOperator(lastTerm)
+		return p.ExpORExp(leftExp, rightExp), nil
+	}
 
-		if firstTermContainsOperator && lastTermContainsOperator {
-			leftExp, err := p.Go(firstTerm)
-			if err != nil {
-				return nil, true, err
-			}
+	if !firstIsTerm {
+		leftExp, err := p.compile(ctx, left)
+		if err != nil {
+			return nil, err
+		}
 
-			rightExp, err := p.Go(lastTerm)
-			if err != nil {
-				return nil, true, err
-			}
+		if p.ExpORStr == nil {
+			return nil, ErrorNotDefinedExpORStr
+		}
 
-			if p.ExpORExp == nil {
-				return nil, true, ErrorNotDefinedExpORExp
-			}
+		return p.ExpORStr(leftExp, lastTerm.Value), nil
+	}
 
-			return p.ExpORExp(leftExp, rightExp), true, nil
+	if !lastIsTerm {
+		rightExp, err := p.compile(ctx, right)
+		if err != nil {
+			return nil, err
+		}
 
+		if p.StrORExp == nil {
+			return nil, ErrorNotDefinedStrORExp
 		}
 
-		if firstTermContainsOperator {
-			leftExp, err := p.Go(firstTerm)
+		return p.StrORExp(firstTerm.Value, rightExp), nil
+	}
 
-			if err != nil {
-				return nil, true, err
-			}
+	if p.StrORStr == nil {
+		return nil, ErrorNotDefinedStrORStr
+	}
 
-			if p.ExpORStr == nil {
-				return nil, true, ErrorNotDefinedExpORStr
-			}
+	return p.StrORStr(firstTerm.Value, lastTerm.Value), nil
+}
 
-			return p.ExpORStr(leftExp, lastTerm), true, nil
-		}
+func (p *parser2) compileAnd(ctx context.Context, left, right Node) (squirrel.Sqlizer, error) {
+	/*
+		Using:
+			ExpANDExp
+			ExpANDStr
+			StrANDExp
+			StrANDStr
+	*/
 
-		if lastTermContainsOperator {
-			rightExp, err := p.Go(lastTerm)
+	if p.Builder != nil {
+		leftExp, rightExp, err := p.compileBoth(ctx, left, right)
+		if err != nil {
+			return nil, err
+		}
 
-			if err != nil {
-				return nil, true, err
-			}
+		return p.Builder.And(leftExp, rightExp), nil
+	}
 
-			if p.StrORExp == nil {
-				return nil, true, ErrorNotDefinedStrORExp
-			}
+	firstTerm, firstIsTerm := p.asTerm(left)
+	lastTerm, lastIsTerm := p.asTerm(right)
 
-			return p.StrORExp(firstTerm, rightExp), true, nil
+	if !firstIsTerm && !lastIsTerm {
+		leftExp, rightExp, err := p.compileBoth(ctx, left, right)
+		if err != nil {
+			return nil, err
 		}
 
-		if p.StrORStr == nil {
-			return nil, true, ErrorNotDefinedStrORStr
+		if p.ExpANDExp == nil {
+			return nil, ErrorNotDefinedExpANDExp
 		}
 
-		return p.StrORStr(firstTerm, lastTerm), true, nil
+		return p.ExpANDExp(leftExp, rightExp), nil
 	}
 
-	if len(terms) > 2 {
-		rightTerms := strings.Join(terms[:len(terms)-1], operatorOr)
-		rightExp, err := p.Go(rightTerms)
-
+	if !firstIsTerm {
+		leftExp, err := p.compile(ctx, left)
 		if err != nil {
-			return nil, true, err
+			return nil, err
 		}
 
-		lastTerm, err := simplify(terms[len(terms)-1])
+		if p.ExpANDStr == nil {
+			return nil, ErrorNotDefinedExpANDStr
+		}
+
+		return p.ExpANDStr(leftExp, lastTerm.Value), nil
+	}
+
+	if !lastIsTerm {
+		rightExp, err := p.compile(ctx, right)
 		if err != nil {
-			return nil, true, err
+			return nil, err
+		}
+
+		if p.StrANDExp == nil {
+			return nil, ErrorNotDefinedStrANDExp
 		}
 
-		lastTermContainsOperator := containsOperator(lastTerm)
-		if lastTermContainsOperator {
-			leftExp, err := p.Go(lastTerm)
+		return p.StrANDExp(firstTerm.Value, rightExp), nil
+	}
 
-			if err != nil {
-				return nil, true, err
-			}
+	if p.StrANDStr == nil {
+		return nil, ErrorNotDefinedStrANDStr
+	}
 
-			if p.ExpORExp == nil {
-				return nil, true, ErrorNotDefinedExpORExp
-			}
+	return p.StrANDStr(firstTerm.Value, lastTerm.Value), nil
+}
 
-			return p.ExpORExp(rightExp, leftExp), true, nil
+func (p *parser2) compileNot(ctx context.Context, operand Node) (squirrel.Sqlizer, error) {
+	if p.Builder != nil {
+		exp, err := p.compile(ctx, operand)
+		if err != nil {
+			return nil, err
 		}
 
-		if p.ExpORStr == nil {
-			return nil, true, ErrorNotDefinedExpORStr
+		return p.Builder.Not(exp), nil
+	}
+
+	term, isTerm := p.asTerm(operand)
+	if !isTerm {
+		exp, err := p.compile(ctx, operand)
+		if err != nil {
+			return nil, err
 		}
 
-		return p.ExpORStr(rightExp, lastTerm), true, nil
+		if p.NotExp == nil {
+			return nil, ErrorNotDefinedNotExp
+		}
+
+		return p.NotExp(exp), nil
 	}
 
-	return nil, false, nil
-}
+	if p.NotStr == nil {
+		return nil, ErrorNotDefinedNotStr
+	}
 
-func (p *parser2) processAnd(s string) (squirrel.Sqlizer, bool, error) {
-	/*
-		Using:
-			ExpANDExp
-			ExpANDStr
-			StrANDExp
-			StrANDStr
-	*/
+	return p.NotStr(term.Value), nil
+}
 
-	st, err := simplify(s)
+func (p *parser2) compileBoth(ctx context.Context, left, right Node) (squirrel.Sqlizer, squirrel.Sqlizer, error) {
+	leftExp, err := p.compile(ctx, left)
 	if err != nil {
-		return nil, true, err
+		return nil, nil, err
 	}
 
-	terms, err := splitAnd(st)
+	rightExp, err := p.compile(ctx, right)
 	if err != nil {
-		return nil, true, err
+		return nil, nil, err
 	}
 
-	if len(terms) == 2 {
-		firstTerm, err := simplify(terms[0])
-		if err == ErrorParentheses {
-			return nil, false, err
-		}
+	return leftExp, rightExp, nil
+}
 
-		lastTerm, err := simplify(terms[1])
-		if err == ErrorParentheses {
-			return nil, false, err
+func (p *parser2) compileAll(ctx context.Context, operands []Node) ([]squirrel.Sqlizer, error) {
+	r := []squirrel.Sqlizer{}
+	for _, operand := range operands {
+		exp, err := p.compile(ctx, operand)
+		if err != nil {
+			return nil, err
 		}
 
-		firstTermContainsOperator := containsOperator(firstTerm)
-		lastTermContainsOperator := containsOperator(lastTerm)
+		r = append(r, exp)
+	}
 
-		if firstTermContainsOperator && lastTermContainsOperator {
-			leftExp, err := p.Go(firstTerm)
-			if err != nil {
-				return nil, true, err
-			}
+	return r, nil
+}
 
-			rightExp, err := p.Go(lastTerm)
-			if err != nil {
-				return nil, true, err
-			}
+// compileNative compiles left and right through native when set,
+// otherwise compiles expanded, the same operation made of and, or and not
+func (p *parser2) compileNative(ctx context.Context, native func(a, b squirrel.Sqlizer) squirrel.Sqlizer, left, right, expanded Node) (squirrel.Sqlizer, error) {
+	if native == nil {
+		return p.compile(ctx, expanded)
+	}
 
-			if p.ExpANDExp == nil {
-				return nil, true, ErrorNotDefinedExpANDExp
-			}
+	leftExp, rightExp, err := p.compileBoth(ctx, left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	return native(leftExp, rightExp), nil
+}
+
+// asTerm tells if n, possibly in parentheses, is a term for Str. A field
+// term goes to its handler, a wildcard goes to Wildcard when set and
+// every term goes to StrContext or StrE when set
+func (p *parser2) asTerm(n Node) (*TermNode, bool) {
+	t, ok := unwrap(n).(*TermNode)
+	return t, ok && t.Field == "" && (!t.Wildcard || p.Wildcard == nil) && p.StrE == nil && p.StrContext == nil
+}
 
-			return p.ExpANDExp(leftExp, rightExp), true, nil
+// hasHandler tells if field has a handler registered by WithField,
+// WithFieldE or WithFieldContext
+func (p *parser2) hasHandler(field string) bool {
+	_, ok := p.Fields[field]
+	_, okE := p.FieldsE[field]
+	_, okContext := p.FieldsContext[field]
 
+	return ok || okE || okContext
+}
+
+// fieldTerms returns the list n as its values written field:value joined
+// by or
+func fieldTerms(n *InNode) Node {
+	var r Node
+	for _, value := range n.Values {
+		var term Node = &TermNode{Field: n.Field, Value: value}
+		if r != nil {
+			term = &OrNode{Left: r, Right: term}
 		}
 
-		if firstTermContainsOperator {
-			leftExp, err := p.Go(firstTerm)
+		r = term
+	}
 
-			if err != nil {
-				return nil, true, err
-			}
+	return r
+}
 
-			if p.ExpANDStr == nil {
-				return nil, true, ErrorNotDefinedExpANDStr
-			}
+func (p *parser2) compileField(ctx context.Context, n *TermNode) (squirrel.Sqlizer, error) {
+	if handler, ok := p.FieldsContext[n.Field]; ok {
+		exp, err := handler(ctx, n.Value)
+		return exp, newCompileError(n, err)
+	}
+
+	if handler, ok := p.FieldsE[n.Field]; ok {
+		exp, err := handler(n.Value)
+		return exp, newCompileError(n, err)
+	}
+
+	handler, ok := p.Fields[n.Field]
+	if !ok {
+		allowed := []string{}
+		for name := range p.Fields {
+			allowed = append(allowed, name)
+		}
+
+		for name := range p.FieldsE {
+			allowed = append(allowed, name)
+		}
 
-			return p.ExpANDStr(leftExp, lastTerm), true, nil
+		for name := range p.FieldsContext {
+			allowed = append(allowed, name)
 		}
 
-		if lastTermContainsOperator {
-			rightExp, err := p.Go(lastTerm)
+		sort.Strings(allowed)
 
+		return nil, &UnknownFieldError{Field: n.Field, Allowed: allowed}
+	}
+
+	return handler(n.Value), nil
+}
+
+func (p *parser2) compile(ctx context.Context, n Node) (squirrel.Sqlizer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch n := n.(type) {
+	case *OrNode:
+		if p.ORAll != nil {
+			operands, err := p.compileAll(ctx, orOperands(n, []Node{}))
 			if err != nil {
-				return nil, true, err
+				return nil, err
 			}
 
-			if p.StrANDExp == nil {
-				return nil, true, ErrorNotDefinedStrANDExp
+			return p.ORAll(operands), nil
+		}
+
+		return p.compileOr(ctx, n.Left, n.Right)
+	case *AndNode:
+		if p.ANDAll != nil {
+			operands, err := p.compileAll(ctx, andOperands(n, []Node{}))
+			if err != nil {
+				return nil, err
 			}
 
-			return p.StrANDExp(firstTerm, rightExp), true, nil
+			return p.ANDAll(operands), nil
 		}
 
-		if p.StrANDStr == nil {
-			return nil, true, ErrorNotDefinedStrANDStr
+		return p.compileAnd(ctx, n.Left, n.Right)
+	case *NotNode:
+		return p.compileNot(ctx, n.Operand)
+	case *XorNode:
+		return p.compileNative(ctx, p.XOR, n.Left, n.Right, n.Expand())
+	case *NandNode:
+		return p.compileNative(ctx, p.NAND, n.Left, n.Right, n.Expand())
+	case *NorNode:
+		return p.compileNative(ctx, p.NOR, n.Left, n.Right, n.Expand())
+	case *ImpliesNode:
+		return p.compileNative(ctx, p.IMPLIES, n.Left, n.Right, n.Expand())
+	case *GroupNode:
+		return p.compile(ctx, n.Inner)
+	case *ComparisonNode:
+		if p.Compare == nil {
+			return nil, ErrorNotDefinedCompare
 		}
 
-		return p.StrANDStr(firstTerm, lastTerm), true, nil
-	}
+		return p.Compare(n), nil
+	case *RangeNode:
+		if p.Range == nil {
+			return nil, ErrorNotDefinedRange
+		}
 
-	if len(terms) > 2 {
-		rightTerms := strings.Join(terms[:len(terms)-1], operatorAnd)
-		rightExp, err := p.Go(rightTerms)
+		return p.Range(n), nil
+	case *InNode:
+		if p.hasHandler(n.Field) && !p.sx().comparisons[n.Field] {
+			return p.compile(ctx, fieldTerms(n))
+		}
 
-		if err != nil {
-			return nil, true, err
+		if p.In == nil {
+			return nil, ErrorNotDefinedIn
 		}
 
-		lastTerm, err := simplify(terms[len(terms)-1])
-		if err != nil {
-			return nil, true, err
+		return p.In(n), nil
+	case *RegexNode:
+		if p.Dialect == nil {
+			return nil, ErrorNotDefinedDialect
 		}
 
-		lastTermContainsOperator := containsOperator(lastTerm)
-		if lastTermContainsOperator {
-			leftExp, err := p.Go(lastTerm)
+		column := n.Field
+		if column == "" {
+			column = p.RegexColumn
+		}
 
-			if err != nil {
-				return nil, true, err
-			}
+		if column == "" {
+			return nil, newCompileError(n, ErrorNotDefinedRegexColumn)
+		}
 
-			if p.ExpANDExp == nil {
-				return nil, true, ErrorNotDefinedExpANDExp
-			}
+		return p.Dialect.Regex(column, n)
+	case *TermNode:
+		if n.Field != "" {
+			return p.compileField(ctx, n)
+		}
 
-			return p.ExpANDExp(rightExp, leftExp), true, nil
+		if n.Wildcard && p.Wildcard != nil {
+			return p.Wildcard(n), nil
 		}
 
-		if p.ExpANDStr == nil {
-			return nil, true, ErrorNotDefinedExpANDStr
+		if p.StrContext != nil {
+			exp, err := p.StrContext(ctx, n.Value)
+			return exp, newCompileError(n, err)
+		}
+
+		if p.StrE != nil {
+			exp, err := p.StrE(n.Value)
+			return exp, newCompileError(n, err)
 		}
 
-		return p.ExpANDStr(rightExp, lastTerm), true, nil
+		// New(nil) makes a StrBuilder that can not build terms
+		if b, ok := p.Builder.(StrBuilder); p.Builder != nil && (!ok || b != nil) {
+			return p.Builder.Term(n.Value), nil
+		}
+
+		if p.Str == nil {
+			return nil, ErrorNotDefinedStr
+		}
+
+		return p.Str(n.Value), nil
 	}
 
-	return nil, false, nil
+	return nil, ErrorExpression
 }
 
-func (p *parser2) processNot(s string) (squirrel.Sqlizer, bool, error) {
-	st, _ := simplify(s)
+// DefaultCompare emits squirrel.Eq, squirrel.NotEq, squirrel.Gt,
+// squirrel.GtOrEq, squirrel.Lt or squirrel.LtOrEq on the column Field
+func DefaultCompare(c *ComparisonNode) squirrel.Sqlizer {
+	switch c.Operator {
+	case compareNotEq:
+		return squirrel.NotEq{c.Field: c.Value}
+	case compareGt:
+		return squirrel.Gt{c.Field: c.Value}
+	case compareGtEq:
+		return squirrel.GtOrEq{c.Field: c.Value}
+	case compareLt:
+		return squirrel.Lt{c.Field: c.Value}
+	case compareLtEq:
+		return squirrel.LtOrEq{c.Field: c.Value}
+	}
 
-	if len(st) >= len(operatorNot) && st[:len(operatorNot)] == operatorNot {
-		term, err := simplify(st[4:])
-		if err != nil {
-			return nil, true, err
-		}
+	return squirrel.Eq{c.Field: c.Value}
+}
 
-		if containsOperator(term) {
-			exp, err := p.Go(term)
+// DefaultRange emits BETWEEN when both bounds are included, otherwise
+// the comparisons of the bounds that are not open. A range without bounds
+// matches a column that is not NULL
+func DefaultRange(r *RangeNode) squirrel.Sqlizer {
+	if r.Lower != "" && r.Upper != "" && r.IncludeLower && r.IncludeUpper {
+		return squirrel.Expr(r.Field+" BETWEEN ? AND ?", r.Lower, r.Upper)
+	}
 
-			if err != nil {
-				return nil, true, err
-			}
+	bounds := squirrel.And{}
 
-			if p.NotExp == nil {
-				return nil, true, ErrorNotDefinedNotExp
-			}
+	if r.Lower != "" && r.IncludeLower {
+		bounds = append(bounds, squirrel.GtOrEq{r.Field: r.Lower})
+	} else if r.Lower != "" {
+		bounds = append(bounds, squirrel.Gt{r.Field: r.Lower})
+	}
 
-			return p.NotExp(exp), true, nil
-		}
+	if r.Upper != "" && r.IncludeUpper {
+		bounds = append(bounds, squirrel.LtOrEq{r.Field: r.Upper})
+	} else if r.Upper != "" {
+		bounds = append(bounds, squirrel.Lt{r.Field: r.Upper})
+	}
 
-		if p.NotStr == nil {
-			return nil, true, ErrorNotDefinedNotStr
-		}
+	switch len(bounds) {
+	case 0:
+		return squirrel.NotEq{r.Field: nil}
+	case 1:
+		return bounds[0]
+	}
+
+	return bounds
+}
+
+// DefaultIn emits squirrel.Eq with the values, that is Field IN (Values)
+func DefaultIn(n *InNode) squirrel.Sqlizer {
+	return squirrel.Eq{n.Field: n.Values}
+}
+
+// Parse builds the tree of s without calling any callback
+func (p *parser2) Parse(s string) (Node, error) {
+	return p.ParseContext(context.Background(), s)
+}
+
+// ParseContext is Parse stopping with the error of ctx once ctx is done
+func (p *parser2) ParseContext(ctx context.Context, s string) (Node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
 
-		exp := p.NotStr(term)
+	n, _, err := p.sx().parseOffsets(ctx, s)
+	return n, err
+}
+
+// Compile turns a tree, rewritten by Optimize when set, into squirrel
+// through the callbacks
+func (p *parser2) Compile(n Node) (squirrel.Sqlizer, error) {
+	return p.compileContext(context.Background(), n)
+}
 
-		return exp, true, nil
+func (p *parser2) compileContext(ctx context.Context, n Node) (squirrel.Sqlizer, error) {
+	if p.err != nil {
+		return nil, p.err
 	}
 
-	return nil, false, nil
+	if p.Optimize != nil {
+		n = p.Optimize(n)
+	}
+
+	return p.compile(ctx, n)
 }
 
 // Go go go
 func (p *parser2) Go(s string) (squirrel.Sqlizer, error) {
-	if !testExpression(s) {
-		return nil, ErrorExpression
-	}
+	return p.GoContext(context.Background(), s)
+}
 
-	if exp, pass, err := p.processOr(s); pass {
-		return exp, err
+// GoContext is Go stopping with the error of ctx once ctx is done. The
+// callbacks set by WithStrContext and WithFieldContext receive ctx
+func (p *parser2) GoContext(ctx context.Context, s string) (squirrel.Sqlizer, error) {
+	if p.err != nil {
+		return nil, p.err
 	}
 
-	if exp, pass, err := p.processAnd(s); pass {
-		return exp, err
+	n, offsets, err := p.sx().parseOffsets(ctx, s)
+	if err != nil {
+		return nil, err
 	}
 
-	if exp, pass, err := p.processNot(s); pass {
-		return exp, err
+	exp, err := p.compileContext(ctx, n)
+	if err != nil {
+		return nil, locate(err, s, offsets)
 	}
 
-	st, _ := simplify(s)
-	if p.Str == nil {
-		return nil, ErrorNotDefinedStr
+	return exp, nil
+}
+
+// sx returns the syntax of p, standard for a parser2 built without New
+func (p *parser2) sx() *syntax {
+	if p.syntax == nil {
+		return standard
 	}
 
-	return p.Str(st), nil
+	return p.syntax
 }
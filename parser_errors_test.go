@@ -3,6 +3,7 @@ package parser
 import (
 	"testing"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -109,3 +110,72 @@ func Test_error_case4(t *testing.T) {
 		assert.Equal(t, ErrorNotDefinedStrORStr, err)
 	}
 }
+
+// Case with any kind of whitespace
+func Test_error_case5(t *testing.T) {
+	assert.True(t, testExpression("alice  and\tbob"))
+	assert.True(t, testExpression("\talice or\n\nbob\r\n"))
+	assert.True(t, testExpression("alice\u00a0and\u3000bob"))
+	assert.True(t, testExpression("(alice)or(bob)"))
+	assert.True(t, testExpression("(alice)and(not(bob))"))
+	assert.True(t, testExpression("not(alice)and not(bob)"))
+	assert.True(t, testExpression("((alice or bob))and(carol)"))
+	assert.True(t, testExpression("( alice or bob )"))
+	assert.True(t, testExpression("(\n  alice\n  or bob\n)\nand carol\n"))
+
+	assert.False(t, testExpression(" \t\n "))
+	assert.False(t, testExpression("alice and\tand bob"))
+	assert.False(t, testExpression("alice\nand"))
+	assert.False(t, testExpression("(alice)or"))
+	assert.False(t, testExpression("(alice)(bob)"))
+	assert.False(t, testExpression("( \t )"))
+	assert.False(t, testExpression("alice\u00a0not\u00a0bob"))
+}
+
+func Test_error_case6(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"alice  and\tbob",
+			[]interface{}{"alice", "bob"},
+			"(col = ? AND col = ?)",
+		},
+		{
+			"(alice)or(bob)",
+			[]interface{}{"alice", "bob"},
+			"(col = ? OR col = ?)",
+		},
+		{
+			"alice\n  or\n  not(bob)and carol\n",
+			[]interface{}{"alice", "bob", "carol"},
+			"(col = ? OR (NOT (col = ?) AND col = ?))",
+		},
+		{
+			"alice \t smith\u3000or\u00a0bob\n\njones",
+			[]interface{}{"alice smith", "bob jones"},
+			"(col = ? OR col = ?)",
+		},
+		{
+			"\"alice\t smith\"\tor bob",
+			[]interface{}{"alice\t smith", "bob"},
+			"(col = ? OR col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		assert.Nil(t, err, curr.input)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
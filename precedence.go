@@ -0,0 +1,97 @@
+package parser
+
+import "fmt"
+
+// Operator names a binary operator in a Precedence table
+type Operator string
+
+// Binary operators
+const (
+	OperatorAnd     Operator = operatorAnd
+	OperatorOr      Operator = operatorOr
+	OperatorXor     Operator = operatorXor
+	OperatorNand    Operator = operatorNand
+	OperatorNor     Operator = operatorNor
+	OperatorImplies Operator = operatorImplies
+)
+
+var operatorKinds = map[Operator]tokenKind{
+	OperatorAnd:     tokenAnd,
+	OperatorOr:      tokenOr,
+	OperatorXor:     tokenXor,
+	OperatorNand:    tokenNand,
+	OperatorNor:     tokenNor,
+	OperatorImplies: tokenImplies,
+}
+
+// Level is a set of operators sharing a precedence. Operators of a level
+// build left-deep chains, "a or b or c" is (a or b) or c, unless
+// RightAssociative, then "a implies b implies c" is a implies (b implies c)
+type Level struct {
+	Operators        []Operator
+	RightAssociative bool
+}
+
+// Precedence lists the levels from the loosest to the tightest binding.
+// Not always binds tighter than any level and an operator missing from
+// the table can not be written
+type Precedence []Level
+
+// StandardPrecedence returns implies, then or and nor, then xor, then
+// and and nand, so "a or b and c" is a or (b and c)
+func StandardPrecedence() Precedence {
+	return Precedence{
+		{Operators: []Operator{OperatorImplies}, RightAssociative: true},
+		{Operators: []Operator{OperatorOr, OperatorNor}},
+		{Operators: []Operator{OperatorXor}},
+		{Operators: []Operator{OperatorAnd, OperatorNand}},
+	}
+}
+
+// LeftToRightPrecedence returns a single level with every operator, so
+// "a or b and c" is (a or b) and c
+func LeftToRightPrecedence() Precedence {
+	return Precedence{
+		{Operators: []Operator{OperatorAnd, OperatorOr, OperatorXor, OperatorNand, OperatorNor, OperatorImplies}},
+	}
+}
+
+// Validate tells if every level has operators and every operator is
+// known and found in one level only
+func (p Precedence) Validate() error {
+	_, err := p.levels()
+	return err
+}
+
+func (p Precedence) levels() ([]level, error) {
+	if len(p) == 0 {
+		return nil, fmt.Errorf("%w: no levels", ErrorPrecedenceInvalid)
+	}
+
+	seen := map[Operator]bool{}
+	levels := []level{}
+	for i, l := range p {
+		if len(l.Operators) == 0 {
+			return nil, fmt.Errorf("%w: level %d has no operators", ErrorPrecedenceInvalid, i)
+		}
+
+		kinds := []tokenKind{}
+		for _, operator := range l.Operators {
+			kind, ok := operatorKinds[operator]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q is not a binary operator", ErrorPrecedenceInvalid, operator)
+			}
+
+			if seen[operator] {
+				return nil, fmt.Errorf("%w: %q is found twice", ErrorPrecedenceInvalid, operator)
+			}
+
+			seen[operator] = true
+			kinds = append(kinds, kind)
+		}
+
+		levels = append(levels, level{kinds: kinds, right: l.RightAssociative})
+	}
+
+	return levels, nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_precedence_modes(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	}
+
+	standard := New(Str)
+	leftToRight := New(Str, WithPrecedence(LeftToRightPrecedence()))
+
+	cases := []struct {
+		input       string
+		standard    string
+		leftToRight string
+	}{
+		{
+			"a or b and c",
+			"(col = ? OR (col = ? AND col = ?))",
+			"((col = ? OR col = ?) AND col = ?)",
+		},
+		{
+			"a and b or c and d",
+			"((col = ? AND col = ?) OR (col = ? AND col = ?))",
+			"(((col = ? AND col = ?) OR col = ?) AND col = ?)",
+		},
+		{
+			"not a or b and (c or d)",
+			"(NOT (col = ?) OR (col = ? AND (col = ? OR col = ?)))",
+			"((NOT (col = ?) OR col = ?) AND (col = ? OR col = ?))",
+		},
+		{
+			"a and b and c",
+			"((col = ? AND col = ?) AND col = ?)",
+			"((col = ? AND col = ?) AND col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		for _, mode := range []struct {
+			p   Parser
+			sql string
+		}{
+			{standard, curr.standard},
+			{leftToRight, curr.leftToRight},
+		} {
+			exp, err := mode.p.Go(curr.input)
+			if !assert.Nil(t, err, curr.input) {
+				continue
+			}
+
+			sql, _, err := exp.ToSql()
+			assert.Nil(t, err)
+			assert.Equal(t, mode.sql, sql, curr.input)
+		}
+	}
+}
+
+func Test_precedence_custom(t *testing.T) {
+	p := New(nil, WithKeywords(ExtendedKeywords()), WithPrecedence(Precedence{
+		{Operators: []Operator{OperatorAnd}, RightAssociative: true},
+		{Operators: []Operator{OperatorOr, OperatorImplies}},
+	}))
+
+	a, b, c, d := &TermNode{Value: "a"}, &TermNode{Value: "b"}, &TermNode{Value: "c"}, &TermNode{Value: "d"}
+
+	n, err := p.Parse("a and b or c and d")
+	assert.Nil(t, err)
+	assert.Equal(t, &AndNode{Left: a, Right: &AndNode{Left: &OrNode{Left: b, Right: c}, Right: d}}, n)
+
+	n, err = p.Parse("a implies b implies c")
+	assert.Nil(t, err)
+	assert.Equal(t, &ImpliesNode{Left: &ImpliesNode{Left: a, Right: b}, Right: c}, n)
+
+	_, err = p.Parse("a xor b")
+	assert.True(t, errors.Is(err, ErrorExpression))
+}
+
+func Test_precedence_with_implicit_and(t *testing.T) {
+	p := New(nil, WithImplicitAnd(), WithPrecedence(LeftToRightPrecedence()))
+
+	a, b, c := &TermNode{Value: "a"}, &TermNode{Value: "b"}, &TermNode{Value: "c"}
+
+	n, err := p.Parse("a or b c")
+	assert.Nil(t, err)
+	assert.Equal(t, &AndNode{Left: &OrNode{Left: a, Right: b}, Right: c}, n)
+}
+
+func Test_precedence_invalid(t *testing.T) {
+	cases := []Precedence{
+		{},
+		{{}},
+		{{Operators: []Operator{OperatorAnd}}, {Operators: []Operator{OperatorOr, OperatorAnd}}},
+		{{Operators: []Operator{"not"}}},
+	}
+
+	for _, curr := range cases {
+		err := curr.Validate()
+		assert.True(t, errors.Is(err, ErrorPrecedenceInvalid), curr)
+		p := New(nil, WithPrecedence(curr))
+
+		_, err = p.Parse("alice")
+		assert.True(t, errors.Is(err, ErrorPrecedenceInvalid), curr)
+
+		_, err = p.Compile(&TermNode{Value: "alice"})
+		assert.True(t, errors.Is(err, ErrorPrecedenceInvalid), curr)
+	}
+
+	assert.Nil(t, StandardPrecedence().Validate())
+	assert.Nil(t, LeftToRightPrecedence().Validate())
+	assert.EqualError(t, cases[2].Validate(), `invalid precedence: "and" is found twice`)
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_quoted_terms(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("col = ?", s)
+	})
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			`"salt and pepper"`,
+			[]interface{}{"salt and pepper"},
+			"col = ?",
+		},
+		{
+			`'not found' or alice`,
+			[]interface{}{"not found", "alice"},
+			"(col = ? OR col = ?)",
+		},
+		{
+			`not "alice or bob"`,
+			[]interface{}{"alice or bob"},
+			"NOT (col = ?)",
+		},
+		{
+			`("(alice)" and 'bob''s') or carol`,
+			[]interface{}{"(alice)", "bob", "s", "carol"},
+			"",
+		},
+		{
+			`"say \"hi\"" and 'it\'s' and "back\\slash"`,
+			[]interface{}{`say "hi"`, "it's", `back\slash`},
+			"((col = ? AND col = ?) AND col = ?)",
+		},
+		{
+			`o'neil and ""`,
+			[]interface{}{"o'neil", ""},
+			"(col = ? AND col = ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if curr.sql == "" {
+			assert.True(t, errors.Is(err, ErrorExpression), curr.input)
+			continue
+		}
+
+		assert.Nil(t, err, curr.input)
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_quoted_terms_are_passed_to_str_variants(t *testing.T) {
+	StrANDStrCalled := false
+	StrANDStr := func(a, b string) squirrel.And {
+		assert.Equal(t, "salt and pepper", a)
+		assert.Equal(t, "or", b)
+
+		StrANDStrCalled = true
+
+		return squirrel.And{}
+	}
+
+	p := parser2{
+		StrANDStr: StrANDStr,
+	}
+
+	_, err := p.Go(`"salt and pepper" and 'or'`)
+	assert.Nil(t, err)
+	assert.True(t, StrANDStrCalled)
+}
+
+func Test_quoted_terms_unterminated(t *testing.T) {
+	cases := []struct {
+		input  string
+		offset int
+		token  string
+	}{
+		{`alice and "bob`, 10, `"`},
+		{`'alice`, 0, `'`},
+		{`"alice\"`, 0, `"`},
+		{`alice or 'bob\`, 9, `'`},
+	}
+
+	for _, curr := range cases {
+		_, err := parse(curr.input)
+		assert.True(t, errors.Is(err, ErrorQuotes), curr.input)
+
+		var syntaxErr *SyntaxError
+		if assert.True(t, errors.As(err, &syntaxErr), curr.input) {
+			assert.Equal(t, curr.offset, syntaxErr.Offset, curr.input)
+			assert.Equal(t, curr.token, syntaxErr.Token, curr.input)
+			assert.Equal(t, "closing "+curr.token, syntaxErr.Expected, curr.input)
+		}
+	}
+}
+
+func Test_quoted_terms_string(t *testing.T) {
+	n, err := parse(`'say "hi"' and "back\\slash" or alice`)
+	assert.Nil(t, err)
+	assert.Equal(t, `"say \"hi\"" and "back\\slash" or alice`, n.String())
+
+	again, err := parse(n.String())
+	assert.Nil(t, err)
+	assert.Equal(t, n, again)
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ranges(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("body LIKE ?", s)
+	}, WithComparisons("price", "created"))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"price:[10 TO 20]",
+			[]interface{}{"10", "20"},
+			"price BETWEEN ? AND ?",
+		},
+		{
+			"price:{10 TO 20}",
+			[]interface{}{"10", "20"},
+			"(price > ? AND price < ?)",
+		},
+		{
+			"price:[10 TO 20}",
+			[]interface{}{"10", "20"},
+			"(price >= ? AND price < ?)",
+		},
+		{
+			"created:[2024-01-01 to *] and not price:{*  TO\t5]",
+			[]interface{}{"2024-01-01", "5"},
+			"(created >= ? AND NOT (price <= ?))",
+		},
+		{
+			"(price:[* TO *])or(cheap)",
+			[]interface{}{"cheap"},
+			"(price IS NOT NULL OR body LIKE ?)",
+		},
+		{
+			"[alice] or {bob}",
+			[]interface{}{"[alice]", "{bob}"},
+			"(body LIKE ? OR body LIKE ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v)
+		assert.Equal(t, curr.sql, sql)
+	}
+}
+
+func Test_ranges_tree(t *testing.T) {
+	p := New(nil, WithComparisons("price"))
+
+	n, err := p.Parse("price:{10 TO *] or price:[* to 2}")
+	assert.Nil(t, err)
+
+	expected := &OrNode{
+		Left:  &RangeNode{Field: "price", Lower: "10", IncludeUpper: true},
+		Right: &RangeNode{Field: "price", Upper: "2", IncludeLower: true},
+	}
+	assert.Equal(t, expected, n)
+	assert.Equal(t, "price:{10 TO *] or price:[* TO 2}", n.String())
+}
+
+func Test_ranges_callback(t *testing.T) {
+	RangeCalled := false
+	Range := func(r *RangeNode) squirrel.Sqlizer {
+		assert.Equal(t, &RangeNode{Field: "price", Lower: "1", Upper: "2", IncludeLower: true, IncludeUpper: true}, r)
+
+		RangeCalled = true
+
+		return squirrel.Expr("price <@ numrange(?, ?, '[]')", r.Lower, r.Upper)
+	}
+
+	p := New(nil, WithComparisons("price"), WithRange(Range))
+
+	_, err := p.Go("price:[1 TO 2]")
+	assert.Nil(t, err)
+	assert.True(t, RangeCalled)
+
+	_, err = (&parser2{}).Compile(&RangeNode{Field: "price"})
+	assert.Equal(t, ErrorNotDefinedRange, err)
+}
+
+func Test_ranges_errors(t *testing.T) {
+	p := New(nil, WithComparisons("price"))
+
+	cases := []struct {
+		input    string
+		offset   int
+		expected string
+		err      error
+	}{
+		{"price:[1 TO 2", 6, "closing ] or }", ErrorRange},
+		{"price:[1 2]", 6, "lower TO upper", ErrorRange},
+		{"price:[1 TO 2 TO 3]", 6, "lower TO upper", ErrorRange},
+		{"cost:[1 TO 2]", 0, "one of price", nil},
+	}
+
+	for _, curr := range cases {
+		_, err := p.Parse(curr.input)
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), curr.input) {
+			continue
+		}
+
+		assert.Equal(t, curr.offset, syntaxErr.Offset, curr.input)
+		assert.Equal(t, curr.expected, syntaxErr.Expected, curr.input)
+		if curr.err != nil {
+			assert.True(t, errors.Is(err, curr.err), curr.input)
+		}
+	}
+}
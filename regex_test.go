@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_regex_dialects(t *testing.T) {
+	Str := func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("message LIKE ?", s)
+	}
+
+	cases := []struct {
+		dialect Dialect
+		input   string
+		values  []interface{}
+		sql     string
+	}{
+		{
+			PostgreSQL,
+			`/err(or)?/ and not source:/^(db|cache)$/i`,
+			[]interface{}{"err(or)?", "^(db|cache)$"},
+			"(message ~ ? AND NOT (source ~* ?))",
+		},
+		{
+			MySQL,
+			`/err(or)?/ and not source:/^(db|cache)$/i`,
+			[]interface{}{"err(or)?", "^(db|cache)$"},
+			"(message REGEXP ? AND NOT (REGEXP_LIKE(source, ?, 'i')))",
+		},
+		{
+			SQLite,
+			`/err(or)?/ and not source:/^(db|cache)$/i`,
+			[]interface{}{"err(or)?", "(?i)^(db|cache)$"},
+			"(message REGEXP ? AND NOT (source REGEXP ?))",
+		},
+		{
+			PostgreSQL,
+			`(/a\/b\d+/)or(timeout) or 1/2`,
+			[]interface{}{`a/b\d+`, "timeout", "1/2"},
+			"((message ~ ? OR message LIKE ?) OR message LIKE ?)",
+		},
+	}
+
+	for _, curr := range cases {
+		p := New(Str, WithComparisons("source"), WithRegex(curr.dialect, "message"))
+
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_regex_tree(t *testing.T) {
+	p := New(nil, WithComparisons("source"), WithRegex(PostgreSQL, "message"))
+
+	n, err := p.Parse(`/a\/b/i or source:/x/`)
+	assert.Nil(t, err)
+
+	expected := &OrNode{
+		Left:  &RegexNode{Pattern: "a/b", Flags: "i"},
+		Right: &RegexNode{Field: "source", Pattern: "x"},
+	}
+	assert.Equal(t, expected, n)
+	assert.Equal(t, `/a\/b/i or source:/x/`, n.String())
+}
+
+func Test_regex_disabled(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Expr("path = ?", s)
+	})
+
+	exp, err := p.Go("/usr/bin/ or /tmp/")
+	assert.Nil(t, err)
+
+	_, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"/usr/bin/", "/tmp/"}, v)
+}
+
+func Test_regex_unsupported(t *testing.T) {
+	p := New(nil, WithRegex(SQLServer, "message"))
+
+	_, err := p.Go("/panic/")
+	assert.True(t, errors.Is(err, ErrorRegexUnsupported))
+	assert.EqualError(t, err, "regex not supported by the dialect: SQL Server can not match /panic/")
+
+	_, err = (&parser2{}).Compile(&RegexNode{Pattern: "x"})
+	assert.Equal(t, ErrorNotDefinedDialect, err)
+
+	_, err = New(nil, WithRegexNames()).Go("/panic/")
+	assert.Equal(t, ErrorNotDefinedDialect, err)
+}
+
+func Test_regex_without_column(t *testing.T) {
+	p := New(nil, WithComparisons("source"), WithRegex(PostgreSQL, ""))
+
+	_, err := p.Go("source:/db/ or /panic/")
+	assert.True(t, errors.Is(err, ErrorNotDefinedRegexColumn))
+
+	var compileErr *CompileError
+	if assert.True(t, errors.As(err, &compileErr)) {
+		assert.Equal(t, 15, compileErr.Offset)
+	}
+
+	exp, err := p.Go("source:/db/")
+	assert.Nil(t, err)
+
+	sql, _, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "source ~ ?", sql)
+}
+
+func Test_regex_errors(t *testing.T) {
+	p := New(nil, WithComparisons("source"), WithRegex(PostgreSQL, "message"))
+
+	cases := []struct {
+		input    string
+		offset   int
+		token    string
+		expected string
+	}{
+		{"alice or /bob", 9, "/", "closing /"},
+		{"/a(b/", 0, "/", "valid regex"},
+		{"/[z-a]/ and bob", 0, "/", "valid regex"},
+		{"/a/im", 4, "m", "flag i"},
+		{"host:/a/", 0, "host:/a/", "one of source"},
+	}
+
+	for _, curr := range cases {
+		_, err := p.Parse(curr.input)
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), curr.input) {
+			continue
+		}
+
+		assert.Equal(t, curr.offset, syntaxErr.Offset, curr.input)
+		assert.Equal(t, curr.token, syntaxErr.Token, curr.input)
+		assert.Equal(t, curr.expected, syntaxErr.Expected, curr.input)
+	}
+
+	_, err := p.Parse("/a(b/")
+	assert.True(t, errors.Is(err, ErrorRegex))
+	assert.EqualError(t, err, "incorrect regex: error parsing regexp: missing closing ): `a(b` at 1:1: expected valid regex, found '/'")
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wildcards(t *testing.T) {
+	p := New(func(s string) squirrel.Sqlizer {
+		return squirrel.Eq{"name": s}
+	}, WithWildcard(LikeTerm("name")))
+
+	cases := []struct {
+		input  string
+		values []interface{}
+		sql    string
+	}{
+		{
+			"ali*",
+			[]interface{}{"ali%"},
+			`name LIKE ? ESCAPE '!'`,
+		},
+		{
+			"*son or a?ice",
+			[]interface{}{"%son", "a_ice"},
+			`(name LIKE ? ESCAPE '!' OR name LIKE ? ESCAPE '!')`,
+		},
+		{
+			`100%* and not _x!y?`,
+			[]interface{}{`100!%%`, `!_x!!y_`},
+			`(name LIKE ? ESCAPE '!' AND NOT (name LIKE ? ESCAPE '!'))`,
+		},
+		{
+			`bob and "ali*"`,
+			[]interface{}{"bob", "ali*"},
+			"(name = ? AND name = ?)",
+		},
+		{
+			"ali* smith or carol",
+			[]interface{}{"ali% smith", "carol"},
+			`(name LIKE ? ESCAPE '!' OR name = ?)`,
+		},
+	}
+
+	for _, curr := range cases {
+		exp, err := p.Go(curr.input)
+		if !assert.Nil(t, err, curr.input) {
+			continue
+		}
+
+		sql, v, err := exp.ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, curr.values, v, curr.input)
+		assert.Equal(t, curr.sql, sql, curr.input)
+	}
+}
+
+func Test_wildcards_ilike(t *testing.T) {
+	p := New(nil, WithWildcard(ILikeTerm("name")))
+
+	exp, err := p.Go("Ali*")
+	assert.Nil(t, err)
+
+	sql, v, err := exp.ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"Ali%"}, v)
+	assert.Equal(t, `name ILIKE ? ESCAPE '!'`, sql)
+}
+
+func Test_wildcards_without_callback(t *testing.T) {
+	StrORStrCalled := false
+	StrORStr := func(a, b string) squirrel.Or {
+		assert.Equal(t, "ali*", a)
+		assert.Equal(t, "b?b", b)
+
+		StrORStrCalled = true
+
+		return squirrel.Or{}
+	}
+
+	p := parser2{
+		StrORStr: StrORStr,
+	}
+
+	_, err := p.Go("ali* or b?b")
+	assert.Nil(t, err)
+	assert.True(t, StrORStrCalled)
+}
+
+func Test_wildcards_tree(t *testing.T) {
+	p := New(nil, WithField("name", nil))
+
+	n, err := p.Parse(`ali* or "bo*" or name:car?l or name:"d*n" or dan`)
+	assert.Nil(t, err)
+
+	expected := &OrNode{
+		Left: &OrNode{
+			Left: &OrNode{
+				Left: &OrNode{
+					Left:  &TermNode{Value: "ali*", Wildcard: true},
+					Right: &TermNode{Value: "bo*", Quoted: true},
+				},
+				Right: &TermNode{Field: "name", Value: "car?l", Wildcard: true},
+			},
+			Right: &TermNode{Field: "name", Value: "d*n", Quoted: true},
+		},
+		Right: &TermNode{Value: "dan"},
+	}
+	assert.Equal(t, expected, n)
+}
+
+func Test_like_escape(t *testing.T) {
+	assert.Equal(t, `a!%b!_c!!d\*e?`, LikeEscape(`a%b_c!d\*e?`))
+	assert.Equal(t, `a!%b!_c!!d\%e_`, LikePattern(`a%b_c!d\*e?`))
+	assert.Equal(t, "%son", termPattern(&TermNode{Value: "*son", Wildcard: true}))
+	assert.Equal(t, "*son", termPattern(&TermNode{Value: "*son", Quoted: true}))
+}